@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	_ "time/tzdata" // business opening hours are in their own timezone, the runtime image has no zoneinfo
 
 	"yalp_ulab/config"
 	"yalp_ulab/internal/app"
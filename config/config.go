@@ -16,6 +16,11 @@ type (
 		JWT   `yaml:"jwt"`
 		Redis `yaml:"redis"`
 		Gmail `yaml:"gmail"`
+		GeoIP `yaml:"geoip"`
+
+		ContentFilter `yaml:"content_filter"`
+		CheckIn       `yaml:"check_in"`
+		Feed          `yaml:"feed"`
 	}
 
 	// App -.
@@ -58,6 +63,28 @@ type (
 		Host      string `env-required:"true" yaml:"host" env:"SMTP_HOST"`
 		Port      string    `env-required:"true" yaml:"port" env:"SMTP_PORT"`
 	}
+
+	// GeoIP -.
+	GeoIP struct {
+		DBPath string `yaml:"db_path" env:"GEOIP_DB_PATH"`
+	}
+
+	// ContentFilter -.
+	ContentFilter struct {
+		Blocklist     []string `yaml:"blocklist" env:"CONTENT_FILTER_BLOCKLIST" env-separator:","`
+		ReviewsPerDay int      `yaml:"reviews_per_day" env:"CONTENT_FILTER_REVIEWS_PER_DAY" env-default:"10"`
+	}
+
+	// CheckIn -.
+	CheckIn struct {
+		RadiusMeters float64 `yaml:"radius_meters" env:"CHECK_IN_RADIUS_METERS" env-default:"200"`
+		DailyLimit   int     `yaml:"daily_limit" env:"CHECK_IN_DAILY_LIMIT" env-default:"20"`
+	}
+
+	// Feed -.
+	Feed struct {
+		CacheTTL int `yaml:"cache_ttl" env:"FEED_CACHE_TTL" env-default:"60"` // seconds, 0 disables the timeline cache
+	}
 )
 
 // NewConfig returns app config.
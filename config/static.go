@@ -17,8 +17,33 @@ var (
 	ErrorConflict       = "CONFLICT"
 	ErrorBadRequest     = "BAD_REQUEST"
 	ErrorDuplicateKey   = "DUPLICATE_KEY"
+	ErrorRequiredField  = "REQUIRED_FIELD"
+	ErrorInvalidValue   = "INVALID_VALUE"
+
+	ErrorTooManyRequests = "TOO_MANY_REQUESTS"
+
+	ErrorPossibleDuplicate = "POSSIBLE_DUPLICATE"
 )
 
 var (
 	TokenExpireTime = 24 * time.Hour * 7 // 7 days
+
+	SessionCacheTTL        = 5 * time.Minute    // how long a validated session stays in Redis
+	SessionIdleTimeout     = 24 * time.Hour * 3 // 3 days without requests deactivates a session
+	SessionLastActiveEvery = time.Minute        // minimum interval between last_active_at writes
+
+	CheckInCooldown = 24 * time.Hour // minimum interval between check-ins of a user at the same business
+
+	MaxMenusPerBusiness = 20
+	MaxCategories       = 1000 // the whole tree is loaded to resolve slugs and descendants
+
+	MaxCalendarDays         = 31   // days of reservations an owner can load at once
+	MaxCalendarReservations = 5000 // confirmed reservations in those days
+
+	MaxPromotionDays   = 365   // longest a deal or event can run
+	MaxPromotionRadius = 50000 // meters around a location promotions are searched in
+
+	DuplicateNameSimilarity = 0.4   // trigram similarity from which two business names may be the same place
+	DuplicateRadius         = 200.0 // meters within which a business with a similar name may be the same place
+	MaxDuplicateCandidates  = 5
 )
@@ -7,12 +7,15 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/casbin/casbin"
 	"github.com/gin-gonic/gin"
 
 	rediscache "github.com/golanguzb70/redis-cache"
 	"yalp_ulab/config"
 	v1 "yalp_ulab/internal/controller/http/v1"
 	"yalp_ulab/internal/usecase"
+	"yalp_ulab/internal/usecase/repo"
+	"yalp_ulab/pkg/geoip"
 	"yalp_ulab/pkg/httpserver"
 	"yalp_ulab/pkg/logger"
 	"yalp_ulab/pkg/postgres"
@@ -41,9 +44,34 @@ func Run(cfg *config.Config) {
 		l.Fatal(fmt.Errorf("app - Run - rediscache.New: %w", err))
 	}
 
+	// GeoIP, optional
+	var geo *geoip.GeoIP
+	if cfg.GeoIP.DBPath != "" {
+		geo, err = geoip.New(cfg.GeoIP.DBPath)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - geoip.New: %w", err))
+		}
+	}
+
+	// Casbin, policies live in Postgres and are kept in sync across instances
+	enforcer, err := casbin.NewSyncedEnforcerSafe("config/rbac.conf", useCase.PolicyRepo)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - casbin.NewSyncedEnforcerSafe: %w", err))
+	}
+
+	err = enforcer.LoadPolicy()
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - enforcer.LoadPolicy: %w", err))
+	}
+
+	policyWatcher := repo.NewPolicyWatcher(pg, cfg, l)
+	defer policyWatcher.Close()
+
+	enforcer.SetWatcher(policyWatcher)
+
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, l, cfg, useCase, redis)
+	v1.NewRouter(handler, l, cfg, useCase, redis, geo, enforcer)
 
 	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
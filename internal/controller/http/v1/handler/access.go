@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// resourceOwner loads a resource and returns the ID of the user owning it.
+func (h *Handler) resourceOwner(ctx *gin.Context, resource, id string) (string, error) {
+	switch resource {
+	case entity.ResourceUser:
+		user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: id})
+		return user.ID, err
+	case entity.ResourceSession:
+		session, err := h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: id})
+		return session.UserID, err
+	case entity.ResourceBusiness:
+		business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: id})
+		return business.CreatedBy, err
+	case entity.ResourcePhoto:
+		photo, err := h.UseCase.PhotoRepo.GetSingle(ctx, entity.Id{ID: id})
+		return photo.UserID, err
+	case entity.ResourceCollection:
+		collection, err := h.UseCase.CollectionRepo.GetSingle(ctx, entity.Id{ID: id})
+		return collection.UserID, err
+	case entity.ResourceReservation:
+		reservation, err := h.UseCase.ReservationRepo.GetSingle(ctx, entity.Id{ID: id})
+		return reservation.UserID, err
+	case entity.ResourceQuestion:
+		question, err := h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: id})
+		return question.UserID, err
+	case entity.ResourceAnswer:
+		answer, err := h.UseCase.AnswerRepo.GetSingle(ctx, entity.Id{ID: id})
+		return answer.UserID, err
+	case entity.ResourceReview:
+		review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: id})
+		return review.UserID, err
+	case entity.ResourceReply:
+		reply, err := h.UseCase.ReviewReplyRepo.GetSingle(ctx, entity.ReviewReplySingleRequest{ReviewID: id})
+		return reply.UserID, err
+	}
+
+	return "", fmt.Errorf("resourceOwner - unknown resource %q", resource)
+}
+
+// AuthorizeResource checks that the current principal owns the resource or is an admin.
+// Like HandleDbError, it writes the error response and returns false when access is denied.
+func (h *Handler) AuthorizeResource(ctx *gin.Context, resource, id string) bool {
+	if GetPrincipal(ctx).IsAdmin() {
+		return true
+	}
+
+	owner, err := h.resourceOwner(ctx, resource, id)
+	if h.HandleDbError(ctx, err, "Error getting "+resource) {
+		return false
+	}
+
+	if owner == "" || owner != GetPrincipal(ctx).UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "You don't have access to this "+resource, http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// userTypeByRole maps every assignable role to the user type it logs in with.
+var userTypeByRole = map[string]string{
+	entity.UserRoleUser:       entity.UserTypeUser,
+	entity.UserRoleAdmin:      entity.UserTypeAdmin,
+	entity.UserRoleSuperAdmin: entity.UserTypeAdmin,
+}
+
+// canManageUser reports whether the current principal may change or delete the given user.
+// Only a superadmin can manage an admin or another superadmin, and nobody can manage themselves.
+func (h *Handler) canManageUser(ctx *gin.Context, user entity.User) (bool, string) {
+	principal := GetPrincipal(ctx)
+
+	if user.ID == principal.UserID {
+		return false, "You can't change your own status or role"
+	}
+
+	if user.UserRole == entity.UserRoleSuperAdmin && principal.UserRole != entity.UserRoleSuperAdmin {
+		return false, "Only a superadmin can manage a superadmin"
+	}
+
+	if user.UserRole == entity.UserRoleAdmin && principal.UserRole != entity.UserRoleSuperAdmin {
+		return false, "Only a superadmin can manage an admin"
+	}
+
+	return true, ""
+}
+
+// canGrantRole reports whether the current principal may grant the given role.
+func (h *Handler) canGrantRole(ctx *gin.Context, role string) bool {
+	return role != entity.UserRoleSuperAdmin || GetPrincipal(ctx).UserRole == entity.UserRoleSuperAdmin
+}
+
+// revokeUserSessions deactivates every session of the given user.
+func (h *Handler) revokeUserSessions(ctx *gin.Context, userID string) error {
+	_, err := h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{
+			{Column: "user_id", Type: "eq", Value: userID},
+			{Column: "is_active", Type: "eq", Value: "true"},
+		},
+		Items: []entity.UpdateFieldItem{
+			{Column: "is_active", Value: "false"},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	h.invalidateUserSessions(ctx, userID)
+
+	return nil
+}
+
+// blockUser blocks the user, revokes all of their sessions and records it in the audit log.
+func (h *Handler) blockUser(ctx *gin.Context, user entity.User, reason string) error {
+	_, err := h.UseCase.UserRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: user.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "status", Value: entity.UserStatusBlocked},
+			{Column: "block_reason", Value: reason},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = h.revokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionUserBlock,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+	}, entity.User{Status: user.Status, BlockReason: user.BlockReason},
+		entity.User{Status: entity.UserStatusBlocked, BlockReason: reason})
+
+	return nil
+}
+
+// BlockUser godoc
+// @Router /admin/user/{id}/block [post]
+// @Summary Block a user
+// @Description Block a user and revoke all of their active sessions
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param body body entity.BlockUserRequest true "Block reason"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) BlockUser(ctx *gin.Context) {
+	var body entity.BlockUserRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	if ok, message := h.canManageUser(ctx, user); !ok {
+		h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+		return
+	}
+
+	err = h.blockUser(ctx, user, body.Reason)
+	if h.HandleDbError(ctx, err, "Error blocking user") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User blocked successfully",
+	})
+}
+
+// UnblockUser godoc
+// @Router /admin/user/{id}/unblock [post]
+// @Summary Unblock a user
+// @Description Unblock a user
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param body body entity.BlockUserRequest true "Unblock reason"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UnblockUser(ctx *gin.Context) {
+	var body entity.BlockUserRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	if ok, message := h.canManageUser(ctx, user); !ok {
+		h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+		return
+	}
+
+	if user.Status != entity.UserStatusBlocked {
+		h.ReturnError(ctx, config.ErrorConflict, "User is not blocked", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.UseCase.UserRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: user.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "status", Value: entity.UserStatusActive},
+			{Column: "block_reason", Value: ""},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error unblocking user") {
+		return
+	}
+
+	h.invalidateUserSessions(ctx, user.ID)
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionUserUnblock,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+	}, gin.H{"status": user.Status, "block_reason": user.BlockReason},
+		gin.H{"status": entity.UserStatusActive, "block_reason": "", "unblock_reason": body.Reason})
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User unblocked successfully",
+	})
+}
+
+// UpdateUserRole godoc
+// @Router /admin/user/{id}/role [put]
+// @Summary Promote or demote a user
+// @Description Change the role of a user. Only a superadmin can grant the superadmin role.
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param body body entity.UpdateUserRoleRequest true "New role"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateUserRole(ctx *gin.Context) {
+	var body entity.UpdateUserRoleRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	userType, ok := userTypeByRole[body.UserRole]
+	if !ok {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid user role", http.StatusBadRequest)
+		return
+	}
+
+	if !h.canGrantRole(ctx, body.UserRole) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only a superadmin can grant the superadmin role", http.StatusForbidden)
+		return
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	if ok, message := h.canManageUser(ctx, user); !ok {
+		h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+		return
+	}
+
+	_, err = h.UseCase.UserRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: user.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "user_role", Value: body.UserRole},
+			{Column: "user_type", Value: userType},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error updating user role") {
+		return
+	}
+
+	// Existing tokens carry the old role, so the user has to log in again.
+	err = h.revokeUserSessions(ctx, user.ID)
+	if h.HandleDbError(ctx, err, "Error revoking user sessions") {
+		return
+	}
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionUserRole,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+	}, entity.User{UserRole: user.UserRole, UserType: user.UserType},
+		entity.User{UserRole: body.UserRole, UserType: userType})
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User role updated successfully",
+	})
+}
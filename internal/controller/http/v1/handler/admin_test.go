@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+const (
+	testAdminID      = "44444444-4444-4444-4444-444444444444"
+	testSuperAdminID = "55555555-5555-5555-5555-555555555555"
+)
+
+// newPrincipalContext returns a test context for a request with the given body made by principal.
+func newPrincipalContext(principal entity.Principal, method, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(method, "/", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	SetPrincipal(ctx, principal)
+
+	return ctx, w
+}
+
+func TestCanManageUser(t *testing.T) {
+	var (
+		user       = entity.User{ID: testUserID, UserRole: entity.UserRoleUser}
+		admin      = entity.User{ID: testAdminID, UserRole: entity.UserRoleAdmin}
+		superAdmin = entity.User{ID: testSuperAdminID, UserRole: entity.UserRoleSuperAdmin}
+		otherAdmin = entity.User{ID: spoofedUserID, UserRole: entity.UserRoleAdmin}
+	)
+
+	tests := []struct {
+		name      string
+		principal entity.Principal
+		target    entity.User
+		allowed   bool
+	}{
+		{"admin manages a user", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, user, true},
+		{"admin manages another admin", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, otherAdmin, false},
+		{"admin manages a superadmin", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, superAdmin, false},
+		{"admin manages themselves", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, admin, false},
+		{"superadmin manages an admin", entity.Principal{UserID: testSuperAdminID, UserRole: entity.UserRoleSuperAdmin}, admin, true},
+		{"superadmin manages another superadmin", entity.Principal{UserID: testSuperAdminID, UserRole: entity.UserRoleSuperAdmin},
+			entity.User{ID: spoofedUserID, UserRole: entity.UserRoleSuperAdmin}, true},
+		{"superadmin manages themselves", entity.Principal{UserID: testSuperAdminID, UserRole: entity.UserRoleSuperAdmin}, superAdmin, false},
+	}
+
+	h := &Handler{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := newPrincipalContext(tt.principal, http.MethodGet, "")
+
+			allowed, message := h.canManageUser(ctx, tt.target)
+			if allowed != tt.allowed {
+				t.Fatalf("canManageUser() = %v (%q), want %v", allowed, message, tt.allowed)
+			}
+			if !allowed && message == "" {
+				t.Fatal("expected a message explaining the refusal")
+			}
+		})
+	}
+}
+
+func TestCanGrantRole(t *testing.T) {
+	tests := []struct {
+		principalRole string
+		role          string
+		allowed       bool
+	}{
+		{entity.UserRoleAdmin, entity.UserRoleUser, true},
+		{entity.UserRoleAdmin, entity.UserRoleAdmin, true},
+		{entity.UserRoleAdmin, entity.UserRoleSuperAdmin, false},
+		{entity.UserRoleSuperAdmin, entity.UserRoleSuperAdmin, true},
+	}
+
+	h := &Handler{}
+
+	for _, tt := range tests {
+		t.Run(tt.principalRole+" grants "+tt.role, func(t *testing.T) {
+			ctx, _ := newPrincipalContext(entity.Principal{UserID: testAdminID, UserRole: tt.principalRole}, http.MethodGet, "")
+
+			if allowed := h.canGrantRole(ctx, tt.role); allowed != tt.allowed {
+				t.Fatalf("canGrantRole() = %v, want %v", allowed, tt.allowed)
+			}
+		})
+	}
+}
+
+// TestUpdateUser_AdminCantTakeOverPrivilegedAccount checks that changing only the email or password of another
+// admin or superadmin goes through canManageUser, not just role and status changes.
+func TestUpdateUser_AdminCantTakeOverPrivilegedAccount(t *testing.T) {
+	admin := entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin, SessionID: testSessionID}
+
+	for _, role := range []string{entity.UserRoleAdmin, entity.UserRoleSuperAdmin} {
+		for _, body := range []string{
+			`{"id": "` + testSuperAdminID + `", "email": "attacker@example.com"}`,
+			`{"id": "` + testSuperAdminID + `", "password": "Attacker-Pass1"}`,
+		} {
+			t.Run(role+" "+body, func(t *testing.T) {
+				h := &Handler{
+					Logger:  logger.New("error"),
+					Config:  &config.Config{},
+					UseCase: &usecase.UseCase{UserRepo: &fakeUserRepo{user: entity.User{ID: testSuperAdminID, UserRole: role}}},
+				}
+
+				ctx, w := newPrincipalContext(admin, http.MethodPut, body)
+				h.UpdateUser(ctx)
+
+				if w.Code != http.StatusForbidden {
+					t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+				}
+			})
+		}
+	}
+}
+
+func TestDeleteUser_AdminCantDeletePrivilegedAccount(t *testing.T) {
+	admin := entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin, SessionID: testSessionID}
+
+	for _, role := range []string{entity.UserRoleAdmin, entity.UserRoleSuperAdmin} {
+		t.Run(role, func(t *testing.T) {
+			h := &Handler{
+				Logger:  logger.New("error"),
+				Config:  &config.Config{},
+				UseCase: &usecase.UseCase{UserRepo: &fakeUserRepo{user: entity.User{ID: testSuperAdminID, UserRole: role}}},
+			}
+
+			ctx, w := newPrincipalContext(admin, http.MethodDelete, "")
+			ctx.Params = gin.Params{{Key: "id", Value: testSuperAdminID}}
+			h.DeleteUser(ctx)
+
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("expected 403, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
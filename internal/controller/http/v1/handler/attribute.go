@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// attributesByKey loads the catalog entries of the given keys. Unknown keys are left out.
+func (h *Handler) attributesByKey(ctx *gin.Context, keys []string) (map[string]entity.Attribute, error) {
+	attributes := make(map[string]entity.Attribute, len(keys))
+	if len(keys) == 0 {
+		return attributes, nil
+	}
+
+	list, err := h.UseCase.AttributeRepo.GetList(ctx, entity.GetListFilter{
+		Limit:   len(keys),
+		Filters: []entity.Filter{{Column: "key", Type: "in", Value: strings.Join(keys, ",")}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attribute := range list.Items {
+		attributes[attribute.Key] = attribute
+	}
+
+	return attributes, nil
+}
+
+// attributeFilters turns `attribute` query values such as "wifi:free", "outdoor_seating" or "seats:>=20"
+// into business list filters. Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) attributeFilters(ctx *gin.Context, values []string) ([]entity.Filter, bool) {
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		keys = append(keys, strings.SplitN(value, ":", 2)[0])
+	}
+
+	attributes, err := h.attributesByKey(ctx, keys)
+	if h.HandleDbError(ctx, err, "Error getting attributes") {
+		return nil, false
+	}
+
+	filters := make([]entity.Filter, 0, len(values))
+	for i, value := range values {
+		attribute, ok := attributes[keys[i]]
+		if !ok {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "attribute",
+				Code:    config.ErrorInvalidValue,
+				Message: keys[i] + " is not a known attribute",
+			})
+			return nil, false
+		}
+
+		path, err := attribute.FilterPath(strings.TrimPrefix(value[len(keys[i]):], ":"))
+		if err != nil {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "attribute",
+				Code:    config.ErrorInvalidValue,
+				Message: err.Error(),
+			})
+			return nil, false
+		}
+
+		filters = append(filters, entity.Filter{
+			Column: "attributes",
+			Type:   "jsonpath",
+			Value:  path,
+		})
+	}
+
+	return filters, true
+}
+
+// CreateAttribute godoc
+// @Router /admin/attribute [post]
+// @Summary Add an attribute to the catalog
+// @Description Add an attribute businesses can be described and filtered by. Enum attributes need their options.
+// @Security BearerAuth
+// @Tags attribute
+// @Accept  json
+// @Produce  json
+// @Param attribute body entity.CreateAttributeRequest true "Attribute object"
+// @Success 201 {object} entity.Attribute
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateAttribute(ctx *gin.Context) {
+	var body entity.CreateAttributeRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if body.Type != entity.AttributeTypeEnum || body.Options == nil {
+		body.Options = []string{}
+	}
+
+	attribute, err := h.UseCase.AttributeRepo.Create(ctx, entity.Attribute{
+		Key:         body.Key,
+		Name:        body.Name,
+		Description: body.Description,
+		Type:        body.Type,
+		Options:     body.Options,
+		Unit:        body.Unit,
+	})
+	if h.HandleDbError(ctx, err, "Error creating attribute") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attribute)
+}
+
+// GetAttributes godoc
+// @Router /attribute/list [get]
+// @Summary Get the attribute catalog
+// @Description Get the attributes businesses can be described and filtered by
+// @Security BearerAuth
+// @Tags attribute
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param type query string false "type"
+// @Success 200 {object} entity.AttributeList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetAttributes(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "100")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	if attributeType := ctx.Query("type"); attributeType != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "type",
+			Type:   "eq",
+			Value:  attributeType,
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "name",
+		Order:  "asc",
+	})
+
+	attributes, err := h.UseCase.AttributeRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting attributes") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attributes)
+}
+
+// UpdateAttribute godoc
+// @Router /admin/attribute [put]
+// @Summary Update an attribute of the catalog
+// @Description Update the name, description, unit or options of an attribute. Values of businesses that are no longer among the options are removed.
+// @Security BearerAuth
+// @Tags attribute
+// @Accept  json
+// @Produce  json
+// @Param attribute body entity.UpdateAttributeRequest true "Attribute object"
+// @Success 200 {object} entity.Attribute
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateAttribute(ctx *gin.Context) {
+	var body entity.UpdateAttributeRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	attribute, err := h.UseCase.AttributeRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting attribute") {
+		return
+	}
+
+	if attribute.Type == entity.AttributeTypeEnum && len(body.Options) == 0 {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "options",
+			Code:    config.ErrorRequiredField,
+			Message: "is required",
+		})
+		return
+	}
+
+	if attribute.Type != entity.AttributeTypeEnum || body.Options == nil {
+		body.Options = []string{}
+	}
+
+	attribute.Name = body.Name
+	attribute.Description = body.Description
+	attribute.Options = body.Options
+	attribute.Unit = body.Unit
+
+	attribute, err = h.UseCase.AttributeRepo.Update(ctx, attribute)
+	if h.HandleDbError(ctx, err, "Error updating attribute") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attribute)
+}
+
+// DeleteAttribute godoc
+// @Router /admin/attribute/{id} [delete]
+// @Summary Delete an attribute from the catalog
+// @Description Delete an attribute and its values from every business
+// @Security BearerAuth
+// @Tags attribute
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Attribute ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteAttribute(ctx *gin.Context) {
+	attribute, err := h.UseCase.AttributeRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting attribute") {
+		return
+	}
+
+	err = h.UseCase.AttributeRepo.Delete(ctx, attribute)
+	if h.HandleDbError(ctx, err, "Error deleting attribute") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Attribute deleted successfully",
+	})
+}
+
+// SetBusinessAttributes godoc
+// @Router /business/{id}/attributes [put]
+// @Summary Set the attributes of your business
+// @Description Replace the attribute values of your business, keyed by attribute key, e.g. {"wifi": "free", "outdoor_seating": true}
+// @Security BearerAuth
+// @Tags attribute
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param attributes body entity.BusinessAttributesRequest true "Attribute values"
+// @Success 200 {object} entity.Business
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) SetBusinessAttributes(ctx *gin.Context) {
+	var body entity.BusinessAttributesRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	keys := make([]string, 0, len(body.Attributes))
+	for key := range body.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attributes, err := h.attributesByKey(ctx, keys)
+	if h.HandleDbError(ctx, err, "Error getting attributes") {
+		return
+	}
+
+	var fields []entity.FieldError
+	for _, key := range keys {
+		attribute, ok := attributes[key]
+		if !ok {
+			fields = append(fields, entity.FieldError{
+				Field:   "attributes." + key,
+				Code:    config.ErrorInvalidValue,
+				Message: "is not a known attribute",
+			})
+			continue
+		}
+
+		if message := attribute.CheckValue(body.Attributes[key]); message != "" {
+			fields = append(fields, entity.FieldError{
+				Field:   "attributes." + key,
+				Code:    config.ErrorInvalidValue,
+				Message: message,
+			})
+		}
+	}
+
+	if len(fields) != 0 {
+		h.ReturnValidationError(ctx, fields...)
+		return
+	}
+
+	err = h.UseCase.BusinessRepo.SetAttributes(ctx, businessID, body.Attributes, GetPrincipal(ctx).UserID)
+	if h.HandleDbError(ctx, err, "Error setting attributes") {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: businessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, business)
+}
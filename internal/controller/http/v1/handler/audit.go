@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/internal/entity"
+)
+
+var (
+	// auditIgnoredFields are never part of an audit diff.
+	auditIgnoredFields = map[string]bool{
+		"updated_at":   true,
+		"access_token": true,
+	}
+
+	// auditMaskedFields are recorded as changed, but their values are never stored.
+	auditMaskedFields = map[string]bool{
+		"password": true,
+	}
+)
+
+const auditMaskedValue = "******"
+
+// RecordAudit stores an audit log entry. Actor, session and IP are taken from the request unless set in entry.
+// Failing to write the entry is logged and never fails the request itself.
+func (h *Handler) RecordAudit(ctx *gin.Context, entry entity.AuditLog, before, after interface{}) {
+	if entry.ActorID == "" {
+		entry.ActorID = GetPrincipal(ctx).UserID
+	}
+
+	if entry.SessionID == "" {
+		entry.SessionID = GetPrincipal(ctx).SessionID
+	}
+
+	entry.IPAddress = ctx.ClientIP()
+	entry.Diff = diffObjects(before, after)
+
+	_, err := h.UseCase.AuditLogRepo.Create(ctx, entry)
+	if err != nil {
+		h.Logger.Error(err, "Error recording audit log")
+	}
+}
+
+// diffObjects compares the JSON representation of two objects field by field.
+func diffObjects(before, after interface{}) map[string]entity.FieldChange {
+	var (
+		b    = toJsonMap(before)
+		a    = toJsonMap(after)
+		diff = map[string]entity.FieldChange{}
+	)
+
+	keys := map[string]bool{}
+	for key := range b {
+		keys[key] = true
+	}
+	for key := range a {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		if auditIgnoredFields[key] || reflect.DeepEqual(b[key], a[key]) {
+			continue
+		}
+
+		change := entity.FieldChange{Before: b[key], After: a[key]}
+		if auditMaskedFields[key] {
+			change = entity.FieldChange{Before: auditMaskedValue, After: auditMaskedValue}
+		}
+
+		diff[key] = change
+	}
+
+	return diff
+}
+
+func toJsonMap(obj interface{}) map[string]interface{} {
+	mp := map[string]interface{}{}
+	if obj == nil {
+		return mp
+	}
+
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return mp
+	}
+
+	_ = json.Unmarshal(body, &mp)
+
+	return mp
+}
+
+// GetAuditLogs godoc
+// @Router /audit [get]
+// @Summary Get a list of audit log entries
+// @Description Get a list of audit log entries
+// @Security BearerAuth
+// @Tags audit
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param actor_id query string false "actor_id"
+// @Param session_id query string false "session_id"
+// @Param action query string false "action"
+// @Param target_type query string false "target_type"
+// @Param target_id query string false "target_id"
+// @Param from query string false "from (RFC3339)"
+// @Param to query string false "to (RFC3339)"
+// @Success 200 {object} entity.AuditLogList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetAuditLogs(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	for _, column := range []string{"actor_id", "session_id", "action", "target_type", "target_id"} {
+		if value := ctx.Query(column); value != "" {
+			req.Filters = append(req.Filters, entity.Filter{
+				Column: column,
+				Type:   "eq",
+				Value:  value,
+			})
+		}
+	}
+
+	if from := ctx.Query("from"); from != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "created_at",
+			Type:   "gte",
+			Value:  from,
+		})
+	}
+
+	if to := ctx.Query("to"); to != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "created_at",
+			Type:   "lte",
+			Value:  to,
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	logs, err := h.UseCase.AuditLogRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting audit logs") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, logs)
+}
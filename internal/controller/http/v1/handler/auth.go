@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +14,88 @@ import (
 	"yalp_ulab/pkg/jwt"
 )
 
+// createSession starts a new session for the user on the current device.
+// When notify is set, the user is notified by email if the device has never been used with their account before.
+func (h *Handler) createSession(ctx *gin.Context, user entity.User, platform string, notify bool) (entity.Session, error) {
+	device := etc.ParseUserAgent(ctx.Request.UserAgent())
+	location := h.GeoIP.Lookup(ctx.ClientIP())
+
+	newSession := entity.Session{
+		UserID:       user.ID,
+		IPAddress:    ctx.ClientIP(),
+		ExpiresAt:    time.Now().Add(config.TokenExpireTime).Format(time.RFC3339),
+		UserAgent:    ctx.Request.UserAgent(),
+		IsActive:     true,
+		LastActiveAt: time.Now().Format(time.RFC3339),
+		Platform:     platform,
+		Browser:      device.Browser,
+		OS:           device.OS,
+		DeviceType:   device.DeviceType,
+		Country:      location.Country,
+		City:         location.City,
+	}
+
+	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
+	if err != nil {
+		return entity.Session{}, err
+	}
+
+	// known devices outlive the sessions, which are deleted on logout
+	newDevice, err := h.UseCase.SessionRepo.RememberDevice(ctx, session)
+	if err != nil {
+		return entity.Session{}, err
+	}
+
+	if newDevice && notify {
+		go h.notifyNewDevice(user.Email, session)
+	}
+
+	return session, nil
+}
+
+// canSignIn checks that the user isn't blocked and signs in to the platform of their user type.
+// It must only run once the password or OTP was verified, so that it tells nothing about an account to someone who
+// only knows its email. Like AuthorizeResource, it writes the error response and returns false on failure.
+func (h *Handler) canSignIn(ctx *gin.Context, user entity.User, platform string) bool {
+	if user.Status == entity.UserStatusBlocked {
+		h.ReturnError(ctx, config.ErrorForbidden, "User is blocked", http.StatusForbidden)
+		return false
+	}
+
+	if user.UserType == "user" && platform == entity.PlatformAdmin {
+		h.ReturnError(ctx, config.ErrorForbidden, "User can't login to admin web", http.StatusBadRequest)
+		return false
+	} else if user.UserType == "admin" && platform != entity.PlatformAdmin {
+		h.ReturnError(ctx, config.ErrorForbidden, "Admin can only login to admin web", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// notifyNewDevice emails the user about a sign-in from a device they haven't used before.
+func (h *Handler) notifyNewDevice(email string, session entity.Session) {
+	place := strings.Trim(session.City+", "+session.Country, ", ")
+
+	emailBody, err := etc.GenerateNewDeviceEmailBody(etc.NewDevice{
+		Browser:    session.Browser,
+		OS:         session.OS,
+		DeviceType: session.DeviceType,
+		IPAddress:  session.IPAddress,
+		Location:   place,
+		Time:       time.Now().Format(time.RFC1123),
+	})
+	if err != nil {
+		h.Logger.Error(err, "Error generating new device email body")
+		return
+	}
+
+	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, email, "New sign-in to your YALP account", emailBody)
+	if err != nil {
+		h.Logger.Error(err, "Error sending new device email")
+	}
+}
+
 // Login godoc
 // @Router /auth/login [post]
 // @Summary Login
@@ -21,14 +104,12 @@ import (
 // @Accept  json
 // @Produce  json
 // @Param body body entity.LoginRequest true "User"
-// @Success 200 {object} entity.SuccessResponse
+// @Success 200 {object} entity.AuthResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) Login(ctx *gin.Context) {
 	var body entity.LoginRequest
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
 		return
 	}
 
@@ -39,31 +120,22 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	if user.UserType == "user" && body.Platform == "admin" {
-		h.ReturnError(ctx, config.ErrorForbidden, "User can't login to admin web", http.StatusBadRequest)
-		return
-	} else if user.UserType == "admin" && body.Platform != "admin" {
-		h.ReturnError(ctx, config.ErrorForbidden, "Admin can only login to admin web", http.StatusBadRequest)
-		return
-	}
-
 	if !hash.CheckPasswordHash(body.Password, user.Password) {
+		h.RecordAudit(ctx, entity.AuditLog{
+			Action:     entity.AuditActionLoginFailed,
+			TargetType: entity.AuditTargetUser,
+			TargetID:   user.ID,
+		}, nil, nil)
 		h.ReturnError(ctx, config.ErrorInvalidPass, "Incorrect password", http.StatusBadRequest)
 		return
 	}
 
-	// Create session
-	newSession := entity.Session{
-		UserID:       user.ID,
-		IPAddress:    ctx.ClientIP(),
-		ExpiresAt:    time.Now().Add(time.Hour * 999999).Format(time.RFC3339),
-		UserAgent:    ctx.Request.UserAgent(),
-		IsActive:     true,
-		LastActiveAt: time.Now().Format(time.RFC3339),
-		Platform:     body.Platform,
+	if !h.canSignIn(ctx, user, body.Platform) {
+		return
 	}
 
-	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
+	// Create session
+	session, err := h.createSession(ctx, user, body.Platform, true)
 	if h.HandleDbError(ctx, err, "Error while creating new session") {
 		return
 	}
@@ -83,9 +155,17 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"user":    user,
-		"session": session,
+	h.RecordAudit(ctx, entity.AuditLog{
+		ActorID:    user.ID,
+		SessionID:  session.ID,
+		Action:     entity.AuditActionLogin,
+		TargetType: entity.AuditTargetSession,
+		TargetID:   session.ID,
+	}, nil, session)
+
+	ctx.JSON(http.StatusOK, entity.AuthResponse{
+		User:    user.ToResponse(),
+		Session: session,
 	})
 }
 
@@ -100,17 +180,25 @@ func (h *Handler) Login(ctx *gin.Context) {
 // @Success 200 {object} entity.SuccessResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) Logout(ctx *gin.Context) {
-	sessionID := ctx.MustGet("session_id")
-	if sessionID == "" {
+	principal := GetPrincipal(ctx)
+	if principal.SessionID == "" {
 		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid session ID", http.StatusBadRequest)
 		return
 	}
 
-	err := h.UseCase.SessionRepo.Delete(ctx, entity.Id{ID: sessionID.(string)})
+	err := h.UseCase.SessionRepo.Delete(ctx, entity.Id{ID: principal.SessionID})
 	if h.HandleDbError(ctx, err, "Error deleting session") {
 		return
 	}
 
+	h.invalidateSession(ctx, principal.UserID, principal.SessionID)
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionLogout,
+		TargetType: entity.AuditTargetSession,
+		TargetID:   principal.SessionID,
+	}, nil, nil)
+
 	ctx.JSON(http.StatusOK, entity.SuccessResponse{
 		Message: "Successfully logged out",
 	})
@@ -124,14 +212,12 @@ func (h *Handler) Logout(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param body body entity.RegisterRequest true "User"
-// @Success 200 {object} entity.User
+// @Success 201 {object} entity.SuccessResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) Register(ctx *gin.Context) {
 	var body entity.RegisterRequest
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
 		return
 	}
 
@@ -176,7 +262,7 @@ func (h *Handler) Register(ctx *gin.Context) {
 		return
 	}
 
-	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, body.Email, emailBody)
+	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, body.Email, "Otp code Mini twitter", emailBody)
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorInternalServer, "Error sending OTP", http.StatusInternalServerError)
 		return
@@ -195,14 +281,12 @@ func (h *Handler) Register(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param body body entity.VerifyEmail true "User"
-// @Success 200 {object} entity.User
+// @Success 200 {object} entity.AuthResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) VerifyEmail(ctx *gin.Context) {
 	var body entity.VerifyEmail
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
 		return
 	}
 
@@ -226,6 +310,10 @@ func (h *Handler) VerifyEmail(ctx *gin.Context) {
 		return
 	}
 
+	if !h.canSignIn(ctx, user, body.Platform) {
+		return
+	}
+
 	user.Status = "active"
 
 	_, err = h.UseCase.UserRepo.Update(ctx, user)
@@ -233,18 +321,8 @@ func (h *Handler) VerifyEmail(ctx *gin.Context) {
 		return
 	}
 
-	// Create session
-	newSession := entity.Session{
-		UserID:       user.ID,
-		IPAddress:    ctx.ClientIP(),
-		ExpiresAt:    time.Now().Add(time.Hour * 999999).Format(time.RFC3339),
-		UserAgent:    ctx.Request.UserAgent(),
-		IsActive:     true,
-		LastActiveAt: time.Now().Format(time.RFC3339),
-		Platform:     body.Platform,
-	}
-
-	session, err := h.UseCase.SessionRepo.Create(ctx, newSession)
+	// The first session of a new account is on the device it was registered from, there is nothing to warn about
+	session, err := h.createSession(ctx, user, body.Platform, false)
 	if h.HandleDbError(ctx, err, "Error while creating new session") {
 		return
 	}
@@ -264,8 +342,16 @@ func (h *Handler) VerifyEmail(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"user":    user,
-		"session": session,
+	h.RecordAudit(ctx, entity.AuditLog{
+		ActorID:    user.ID,
+		SessionID:  session.ID,
+		Action:     entity.AuditActionVerifyEmail,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+	}, entity.User{Status: entity.UserStatusInVerify}, entity.User{Status: user.Status})
+
+	ctx.JSON(http.StatusOK, entity.AuthResponse{
+		User:    user.ToResponse(),
+		Session: session,
 	})
 }
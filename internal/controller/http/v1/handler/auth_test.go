@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/hash"
+	"yalp_ulab/pkg/logger"
+)
+
+// TestLogin_WrongPasswordRevealsNothing checks that blocked and admin accounts answer a wrong password the same way
+// as any other account, so their status and type can't be probed with an email alone.
+func TestLogin_WrongPasswordRevealsNothing(t *testing.T) {
+	password, err := hash.HashPassword("Right-Pass1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		user     entity.User
+		password string
+		platform string
+		status   int
+		code     string
+	}{
+		{"blocked, wrong password", entity.User{UserType: "user", Status: entity.UserStatusBlocked}, "Wrong-Pass1", entity.PlatformWeb,
+			http.StatusBadRequest, config.ErrorInvalidPass},
+		{"admin on web, wrong password", entity.User{UserType: "admin", Status: entity.UserStatusActive}, "Wrong-Pass1", entity.PlatformWeb,
+			http.StatusBadRequest, config.ErrorInvalidPass},
+		{"user on admin, wrong password", entity.User{UserType: "user", Status: entity.UserStatusActive}, "Wrong-Pass1", entity.PlatformAdmin,
+			http.StatusBadRequest, config.ErrorInvalidPass},
+		{"blocked, right password", entity.User{UserType: "user", Status: entity.UserStatusBlocked}, "Right-Pass1", entity.PlatformWeb,
+			http.StatusForbidden, config.ErrorForbidden},
+		{"admin on web, right password", entity.User{UserType: "admin", Status: entity.UserStatusActive}, "Right-Pass1", entity.PlatformWeb,
+			http.StatusBadRequest, config.ErrorForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.user.ID = testUserID
+			tt.user.Email = "someone@example.com"
+			tt.user.Password = password
+
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{
+					UserRepo:     &fakeUserRepo{user: tt.user},
+					AuditLogRepo: &fakeAuditLogRepo{},
+				},
+			}
+
+			ctx, w := newPrincipalContext(entity.Principal{}, http.MethodPost,
+				`{"email": "someone@example.com", "password": "`+tt.password+`", "platform": "`+tt.platform+`"}`)
+			h.Login(ctx)
+
+			var response entity.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if w.Code != tt.status || response.Code != tt.code {
+				t.Fatalf("expected %d %s, got %d %s: %s", tt.status, tt.code, w.Code, response.Code, response.Message)
+			}
+		})
+	}
+}
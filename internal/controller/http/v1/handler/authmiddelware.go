@@ -1,66 +1,43 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 
-	"github.com/casbin/casbin"
 	"github.com/gin-gonic/gin"
-	"yalp_ulab/internal/entity"
 	"yalp_ulab/pkg/jwt"
 )
 
-func (h *Handler) AuthMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
+const roleUnauthorized = "unauthorized"
+
+func (h *Handler) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var (
-			userRole string
+			userRole = roleUnauthorized
 			act      = c.Request.Method
 			obj      = c.FullPath()
 		)
 
-		token := c.GetHeader("Authorization")
-		if token == "" {
-			userRole = "unauthorized"
-		}
-
-		if userRole == "" {
-			token = strings.TrimPrefix(token, "Bearer ")
-
+		// Identity only ever comes from a verified token, never from request headers.
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token != "" {
 			claims, err := jwt.ParseJWT(token, h.Config.JWT.Secret)
-			if err != nil {
-				userRole = "unauthorized"
-			}
-
-			v, ok := claims["user_role"].(string)
-			if !ok {
-				userRole = "unauthorized"
-			} else {
-				userRole = v
-			}
-
-			for key, value := range claims {
-				c.Request.Header.Set(key, fmt.Sprintf("%v", value))
-			}
-		}
-
-		// TO DO: Check if session is valid
-
-		if userRole != "unauthorized" {
-			session, err := h.UseCase.SessionRepo.GetSingle(c, entity.Id{ID: c.GetHeader("session_id")})
-			if err != nil {
-				fmt.Println("error while gettign single session", err)
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session is invalid"})
-				return
-			}
-
-			if !session.IsActive {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session is not active"})
-				return
+			if err == nil {
+				principal := principalFromClaims(claims)
+				if principal.IsAuthenticated() && principal.UserRole != "" {
+					status, message := h.validateSession(c, principal.UserID, principal.SessionID)
+					if status != 0 {
+						c.AbortWithStatusJSON(status, gin.H{"error": message})
+						return
+					}
+
+					userRole = principal.UserRole
+					SetPrincipal(c, principal)
+				}
 			}
 		}
 
-		ok, err := e.EnforceSafe(userRole, obj, act)
+		ok, err := h.Enforcer.EnforceSafe(userRole, obj, act)
 		if err != nil {
 			h.Logger.Error(err, "Error enforcing policy")
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "access denied"})
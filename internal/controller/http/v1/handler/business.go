@@ -1,70 +1,163 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"yalp_ulab/config"
 	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/contentfilter"
 )
 
+// getVisibleBusiness loads a business. Businesses that are not published are only visible to their owner and admins.
+func (h *Handler) getVisibleBusiness(ctx *gin.Context, id string) (entity.Business, bool) {
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: id})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return business, false
+	}
+
+	return business, h.checkBusinessVisible(ctx, business)
+}
+
+// checkBusinessVisible responds with not found unless the business is published or the principal is its owner or an admin.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) checkBusinessVisible(ctx *gin.Context, business entity.Business) bool {
+	principal := GetPrincipal(ctx)
+	if business.Status != entity.ContentStatusPublished && business.CreatedBy != principal.UserID && !principal.IsAdmin() {
+		h.ReturnError(ctx, config.ErrorNotFound, "Business not found", http.StatusNotFound)
+		return false
+	}
+
+	return true
+}
+
+// parsePriceLevels checks a comma separated list of price levels, e.g. "1,2".
+func parsePriceLevels(value string) bool {
+	for _, level := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(level)
+		if err != nil || n < entity.PriceLevelMin || n > entity.PriceLevelMax {
+			return false
+		}
+	}
+	return true
+}
+
 // CreateBusiness godoc
 // @Router /business [post]
 // @Summary Create a new business
-// @Description Create a new business
+// @Description Create a new business. Businesses flagged by the content filter are created as pending until a moderator looks at them.
+// @Description When a published business nearby has a similar name, nothing is created and the possible duplicates are returned
+// @Description with 409. Send force=true to create the business anyway.
 // @Security BearerAuth
 // @Tags business
 // @Accept  json
 // @Produce  json
 // @Param business body entity.Business true "Business object"
+// @Param force query bool false "create even if there are possible duplicates"
 // @Success 201 {object} entity.Business
 // @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.DuplicateWarning
 func (h *Handler) CreateBusiness(ctx *gin.Context) {
 	var body entity.Business
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	body.CreatedBy = GetPrincipal(ctx).UserID
+	body.Status = entity.ContentStatusPublished
+	body.Attributes = map[string]interface{}{} // set with SetBusinessAttributes once the business exists
+	body.OpeningHours = entity.OpeningHours{Timezone: "UTC", Periods: []entity.OpeningPeriod{}}
+
+	ids, ok := h.categoryIDs(ctx, body.Categories)
+	if !ok {
+		return
+	}
+	body.CategoryIDs = ids
+	body.TextHash = contentfilter.Fingerprint(businessText(body))
+
+	if ctx.Query("force") != "true" {
+		candidates, err := h.findDuplicates(ctx, body.Name, body.Location, "")
+		if h.HandleDbError(ctx, err, "Error looking for duplicate businesses") {
+			return
+		}
+
+		if len(candidates) != 0 {
+			ctx.JSON(http.StatusConflict, entity.DuplicateWarning{
+				Message:    "A business with a similar name already exists nearby, send force=true to create it anyway",
+				Code:       config.ErrorPossibleDuplicate,
+				Candidates: candidates,
+			})
+			return
+		}
+	}
+
+	result, err := h.BusinessFilter.Screen(ctx, contentfilter.Content{AuthorID: body.CreatedBy, Text: businessText(body)})
+	if h.HandleDbError(ctx, err, "Error screening business") {
 		return
 	}
 
+	if result.Flagged() {
+		body.Status = entity.ContentStatusPending
+	}
+
 	business, err := h.UseCase.BusinessRepo.Create(ctx, body)
 	if h.HandleDbError(ctx, err, "Error creating business") {
 		return
 	}
 
+	if result.Flagged() {
+		err = h.queueFlaggedContent(ctx, entity.ReportTargetBusiness, business.ID, business.CreatedBy, result)
+		if h.HandleDbError(ctx, err, "Error queueing business for moderation") {
+			return
+		}
+	}
+
 	ctx.JSON(http.StatusCreated, business)
 }
 
 // GetBusiness godoc
 // @Router /business/{id} [get]
 // @Summary Get a business by ID
-// @Description Get a business by ID
+// @Description Get a business by ID. A business that was merged into another one redirects to it.
 // @Security BearerAuth
 // @Tags business
 // @Accept  json
 // @Produce  json
 // @Param id path string true "Business ID"
 // @Success 200 {object} entity.Business
+// @Success 301
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBusiness(ctx *gin.Context) {
-	var req entity.BusinessSingleRequest
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
 
-	req.ID = ctx.Param("id")
+	if business.MergedInto != "" {
+		ctx.Redirect(http.StatusMovedPermanently, "/v1/business/"+business.MergedInto)
+		return
+	}
 
-	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, req)
-	if h.HandleDbError(ctx, err, "Error getting business") {
+	if !h.checkBusinessVisible(ctx, business) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, business)
+	businesses := []entity.Business{business}
+	h.setBookmarked(ctx, businesses)
+
+	ctx.JSON(http.StatusOK, businesses[0])
 }
 
 // GetBusinesses godoc
 // @Router /business/list [get]
 // @Summary Get a list of businesses
-// @Description Get a list of businesses
+// @Description Get a list of businesses. price_level takes one or more comma separated levels from 1 ($) to 4 ($$$$), e.g. "1,2".
+// @Description category is a category slug, businesses in its subcategories are included.
+// @Description Each attribute filter is an attribute key with an optional value, e.g. "outdoor_seating", "wifi:free" or "seats:>=20".
 // @Security BearerAuth
 // @Tags business
 // @Accept  json
@@ -72,6 +165,9 @@ func (h *Handler) GetBusiness(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
+// @Param category query string false "category"
+// @Param price_level query string false "price_level"
+// @Param attribute query []string false "attribute" collectionFormat(multi)
 // @Success 200 {object} entity.BusinessList
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetBusinesses(ctx *gin.Context) {
@@ -85,22 +181,57 @@ func (h *Handler) GetBusinesses(ctx *gin.Context) {
 	req.Limit, _ = strconv.Atoi(limit)
 	req.Filters = append(req.Filters,
 		entity.Filter{
-			Column: "business_name",
-			Type:   "search",
-			Value:  search,
+			Column: "status",
+			Type:   "eq",
+			Value:  entity.ContentStatusPublished,
 		},
 		entity.Filter{
-			Column: "category",
+			Column: "business_name",
 			Type:   "search",
 			Value:  search,
 		},
 		entity.Filter{
-			Column: "location",
+			Column: "location::text",
 			Type:   "search",
 			Value:  search,
 		},
 	)
 
+	if category := ctx.Query("category"); category != "" {
+		filter, ok := h.categoryFilter(ctx, category)
+		if !ok {
+			return
+		}
+
+		req.Filters = append(req.Filters, filter)
+	}
+
+	if priceLevel := ctx.Query("price_level"); priceLevel != "" {
+		if !parsePriceLevels(priceLevel) {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "price_level",
+				Code:    config.ErrorInvalidValue,
+				Message: fmt.Sprintf("must be a comma separated list of levels from %d to %d", entity.PriceLevelMin, entity.PriceLevelMax),
+			})
+			return
+		}
+
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "price_level",
+			Type:   "in",
+			Value:  priceLevel,
+		})
+	}
+
+	if values := ctx.QueryArray("attribute"); len(values) != 0 {
+		filters, ok := h.attributeFilters(ctx, values)
+		if !ok {
+			return
+		}
+
+		req.Filters = append(req.Filters, filters...)
+	}
+
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
 		Column: "created_at",
 		Order:  "desc",
@@ -111,13 +242,15 @@ func (h *Handler) GetBusinesses(ctx *gin.Context) {
 		return
 	}
 
+	h.setBookmarked(ctx, businesses.Items)
+
 	ctx.JSON(http.StatusOK, businesses)
 }
 
 // UpdateBusiness godoc
 // @Router /business [put]
 // @Summary Update a business
-// @Description Update a business
+// @Description Update a business. A published business flagged by the content filter goes back to pending.
 // @Security BearerAuth
 // @Tags business
 // @Accept  json
@@ -128,17 +261,51 @@ func (h *Handler) GetBusinesses(ctx *gin.Context) {
 func (h *Handler) UpdateBusiness(ctx *gin.Context) {
 	var body entity.Business
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, body.ID) {
+		return
+	}
+
+	// Admins can edit any business, screen it as its owner's
+	before, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	result, err := h.BusinessFilter.Screen(ctx, contentfilter.Content{ID: before.ID, AuthorID: before.CreatedBy, Text: businessText(body)})
+	if h.HandleDbError(ctx, err, "Error screening business") {
 		return
 	}
 
-	business, err := h.UseCase.BusinessRepo.Update(ctx, body)
+	ids, ok := h.categoryIDs(ctx, body.Categories)
+	if !ok {
+		return
+	}
+
+	body.CategoryIDs = ids
+	body.TextHash = contentfilter.Fingerprint(businessText(body))
+	body.UpdatedBy = GetPrincipal(ctx).UserID
+
+	_, err = h.UseCase.BusinessRepo.Update(ctx, body)
 	if h.HandleDbError(ctx, err, "Error updating business") {
 		return
 	}
 
+	if result.Flagged() {
+		err = h.holdFlaggedContent(ctx, entity.ReportTargetBusiness, before.ID, before.CreatedBy, result)
+		if h.HandleDbError(ctx, err, "Error queueing business for moderation") {
+			return
+		}
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, business)
 }
 
@@ -158,6 +325,10 @@ func (h *Handler) DeleteBusiness(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, req.ID) {
+		return
+	}
+
 	err := h.UseCase.BusinessRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting business") {
 		return
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// requestLanguage is the language to show names in: the lang query parameter, or else the first Accept-Language tag.
+func requestLanguage(ctx *gin.Context) string {
+	lang := ctx.Query("lang")
+	if lang == "" {
+		lang = strings.SplitN(ctx.GetHeader("Accept-Language"), ",", 2)[0]
+	}
+
+	// "uz-Latn-UZ;q=0.9" is "uz"
+	lang = strings.SplitN(strings.SplitN(lang, ";", 2)[0], "-", 2)[0]
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return entity.DefaultLanguage
+	}
+
+	return lang
+}
+
+// loadCategories loads the whole category tree as a flat list.
+func (h *Handler) loadCategories(ctx *gin.Context) ([]entity.Category, error) {
+	categories, err := h.UseCase.CategoryRepo.GetList(ctx, entity.GetListFilter{Limit: config.MaxCategories})
+	return categories.Items, err
+}
+
+// categoryIDs resolves the category slugs of a business to IDs.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) categoryIDs(ctx *gin.Context, slugs []string) ([]string, bool) {
+	categories, err := h.UseCase.CategoryRepo.GetList(ctx, entity.GetListFilter{
+		Limit:   len(slugs),
+		Filters: []entity.Filter{{Column: "slug", Type: "in", Value: strings.Join(slugs, ",")}},
+	})
+	if h.HandleDbError(ctx, err, "Error getting categories") {
+		return nil, false
+	}
+
+	bySlug := make(map[string]string, len(categories.Items))
+	for _, category := range categories.Items {
+		bySlug[category.Slug] = category.ID
+	}
+
+	var (
+		ids    []string
+		fields []entity.FieldError
+		seen   = map[string]bool{}
+	)
+
+	for i, slug := range slugs {
+		id, ok := bySlug[slug]
+		if !ok {
+			fields = append(fields, entity.FieldError{
+				Field:   fmt.Sprintf("categories[%d]", i),
+				Code:    config.ErrorInvalidValue,
+				Message: "is not a known category",
+			})
+			continue
+		}
+
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(fields) != 0 {
+		h.ReturnValidationError(ctx, fields...)
+		return nil, false
+	}
+
+	return ids, true
+}
+
+// categoryFilter matches businesses in the category with the given slug or any category below it.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) categoryFilter(ctx *gin.Context, slug string) (entity.Filter, bool) {
+	categories, err := h.loadCategories(ctx)
+	if h.HandleDbError(ctx, err, "Error getting categories") {
+		return entity.Filter{}, false
+	}
+
+	for _, category := range categories {
+		if category.Slug == slug {
+			return entity.Filter{
+				Column: "category_ids",
+				Type:   "overlap",
+				Value:  strings.Join(entity.CategoryDescendants(categories, category.ID), ","),
+			}, true
+		}
+	}
+
+	h.ReturnValidationError(ctx, entity.FieldError{
+		Field:   "category",
+		Code:    config.ErrorInvalidValue,
+		Message: "is not a known category",
+	})
+	return entity.Filter{}, false
+}
+
+// checkCategory checks that a category has a name in the default language and that its parent exists
+// and is not the category itself or below it. Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) checkCategory(ctx *gin.Context, category entity.Category) bool {
+	if category.Names[entity.DefaultLanguage] == "" {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "names." + entity.DefaultLanguage,
+			Code:    config.ErrorRequiredField,
+			Message: "is required",
+		})
+		return false
+	}
+
+	if category.ParentID == "" {
+		return true
+	}
+
+	categories, err := h.loadCategories(ctx)
+	if h.HandleDbError(ctx, err, "Error getting categories") {
+		return false
+	}
+
+	found := false
+	for _, c := range categories {
+		found = found || c.ID == category.ParentID
+	}
+
+	if !found {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "parent_id",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be an existing category",
+		})
+		return false
+	}
+
+	if category.ID != "" && contains(entity.CategoryDescendants(categories, category.ID), category.ParentID) {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "parent_id",
+			Code:    config.ErrorInvalidValue,
+			Message: "can't be the category itself or one of its subcategories",
+		})
+		return false
+	}
+
+	return true
+}
+
+// GetCategoryTree godoc
+// @Router /category/tree [get]
+// @Summary Get the category tree
+// @Description Get all categories nested under their parents. Names are in the lang query parameter or Accept-Language, English by default.
+// @Security BearerAuth
+// @Tags category
+// @Accept  json
+// @Produce  json
+// @Param lang query string false "lang"
+// @Success 200 {object} entity.CategoryList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCategoryTree(ctx *gin.Context) {
+	categories, err := h.loadCategories(ctx)
+	if h.HandleDbError(ctx, err, "Error getting categories") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.CategoryList{
+		Items: entity.CategoryTree(categories, requestLanguage(ctx)),
+		Count: len(categories),
+	})
+}
+
+// CreateCategory godoc
+// @Router /admin/category [post]
+// @Summary Add a category
+// @Description Add a category, top-level or below parent_id. It needs at least an English name.
+// @Security BearerAuth
+// @Tags category
+// @Accept  json
+// @Produce  json
+// @Param category body entity.CreateCategoryRequest true "Category object"
+// @Success 201 {object} entity.Category
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateCategory(ctx *gin.Context) {
+	var body entity.CreateCategoryRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	category := entity.Category{
+		ParentID: body.ParentID,
+		Slug:     body.Slug,
+		Names:    body.Names,
+		Icon:     body.Icon,
+		Position: body.Position,
+	}
+
+	if !h.checkCategory(ctx, category) {
+		return
+	}
+
+	category, err := h.UseCase.CategoryRepo.Create(ctx, category)
+	if h.HandleDbError(ctx, err, "Error creating category") {
+		return
+	}
+
+	category.Name = category.LocalName(requestLanguage(ctx))
+
+	ctx.JSON(http.StatusCreated, category)
+}
+
+// UpdateCategory godoc
+// @Router /admin/category [put]
+// @Summary Update a category
+// @Description Rename, move or reorder a category. Its subcategories and businesses move with it.
+// @Security BearerAuth
+// @Tags category
+// @Accept  json
+// @Produce  json
+// @Param category body entity.UpdateCategoryRequest true "Category object"
+// @Success 200 {object} entity.Category
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateCategory(ctx *gin.Context) {
+	var body entity.UpdateCategoryRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	category := entity.Category{
+		ID:       body.ID,
+		ParentID: body.ParentID,
+		Slug:     body.Slug,
+		Names:    body.Names,
+		Icon:     body.Icon,
+		Position: body.Position,
+	}
+
+	if !h.checkCategory(ctx, category) {
+		return
+	}
+
+	category, err := h.UseCase.CategoryRepo.Update(ctx, category)
+	if h.HandleDbError(ctx, err, "Error updating category") {
+		return
+	}
+
+	category.Name = category.LocalName(requestLanguage(ctx))
+
+	ctx.JSON(http.StatusOK, category)
+}
+
+// DeleteCategory godoc
+// @Router /admin/category/{id} [delete]
+// @Summary Delete a category
+// @Description Delete a category without subcategories. Businesses in it keep their other categories.
+// @Security BearerAuth
+// @Tags category
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Category ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) DeleteCategory(ctx *gin.Context) {
+	var req entity.Id
+
+	req.ID = ctx.Param("id")
+
+	children, err := h.UseCase.CategoryRepo.GetList(ctx, entity.GetListFilter{
+		Limit:   1,
+		Filters: []entity.Filter{{Column: "parent_id", Type: "eq", Value: req.ID}},
+	})
+	if h.HandleDbError(ctx, err, "Error getting subcategories") {
+		return
+	}
+
+	if children.Count > 0 {
+		h.ReturnError(ctx, config.ErrorConflict, "Move or delete the subcategories of this category first", http.StatusConflict)
+		return
+	}
+
+	err = h.UseCase.CategoryRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting category") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Category deleted successfully",
+	})
+}
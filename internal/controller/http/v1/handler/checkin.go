@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// CheckIn godoc
+// @Router /business/{id}/checkin [post]
+// @Summary Check in at a business
+// @Description Check in at a business you are at. The location must be within the configured radius of the business.
+// @Description A business can be checked in once per cooldown, and check-ins per day are limited.
+// @Security BearerAuth
+// @Tags checkin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param checkin body entity.CheckInRequest true "Current location"
+// @Success 201 {object} entity.CheckIn
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 429 {object} entity.ErrorResponse
+func (h *Handler) CheckIn(ctx *gin.Context) {
+	var body entity.CheckInRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	if business.Status != entity.ContentStatusPublished {
+		h.ReturnError(ctx, config.ErrorNotFound, "Business not found", http.StatusNotFound)
+		return
+	}
+
+	distance := business.Location.DistanceTo(body.Location)
+	if distance > h.Config.CheckIn.RadiusMeters {
+		h.ReturnError(ctx, config.ErrorBadRequest,
+			fmt.Sprintf("You are %.0f meters away, check-ins are allowed within %.0f meters", distance, h.Config.CheckIn.RadiusMeters),
+			http.StatusBadRequest)
+		return
+	}
+
+	checkIn, err := h.UseCase.CheckInRepo.Create(ctx, entity.CheckIn{
+		UserID:         GetPrincipal(ctx).UserID,
+		BusinessID:     business.ID,
+		BusinessName:   business.Name,
+		DistanceMeters: distance,
+	}, h.Config.CheckIn.DailyLimit)
+	if err == entity.ErrCheckInCooldown {
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "You already checked in here recently", http.StatusTooManyRequests)
+		return
+	}
+	if err == entity.ErrCheckInDailyLimit {
+		h.ReturnError(ctx, config.ErrorTooManyRequests, "Daily check-in limit reached", http.StatusTooManyRequests)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error creating check-in") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, checkIn)
+}
+
+// GetUserCheckIns godoc
+// @Router /user/{id}/checkins [get]
+// @Summary Get the visit history of a user
+// @Description Get the check-ins of a user, newest first, if the user's privacy settings allow it.
+// @Security BearerAuth
+// @Tags checkin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param business_id query string false "business_id"
+// @Success 200 {object} entity.CheckInList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetUserCheckIns(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	record, ok := h.getVisibleProfile(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	visible, err := h.profilePartVisible(ctx, record.ID, record.Privacy.CheckIns)
+	if h.HandleDbError(ctx, err, "Error checking profile privacy") {
+		return
+	}
+
+	if !visible {
+		h.ReturnError(ctx, config.ErrorForbidden, "This user's visit history is private", http.StatusForbidden)
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "c.user_id",
+		Type:   "eq",
+		Value:  record.ID,
+	})
+
+	if businessID := ctx.Query("business_id"); businessID != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "c.business_id",
+			Type:   "eq",
+			Value:  businessID,
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "c.created_at",
+		Order:  "desc",
+	})
+
+	checkIns, err := h.UseCase.CheckInRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting check-ins") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checkIns)
+}
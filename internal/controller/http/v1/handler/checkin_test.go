@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+// fakeCheckInRepo fails every check-in with err and keeps the daily limit it was given.
+type fakeCheckInRepo struct {
+	usecase.CheckInRepoI
+	err        error
+	dailyLimit int
+}
+
+func (r *fakeCheckInRepo) Create(ctx context.Context, req entity.CheckIn, dailyLimit int) (entity.CheckIn, error) {
+	r.dailyLimit = dailyLimit
+	return req, r.err
+}
+
+func TestCheckIn_Limits(t *testing.T) {
+	user := entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser, SessionID: testSessionID}
+	location := entity.Location{Latitude: 41.3111, Longitude: 69.2797}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"allowed", nil, http.StatusCreated},
+		{"cooldown", entity.ErrCheckInCooldown, http.StatusTooManyRequests},
+		{"daily limit", entity.ErrCheckInDailyLimit, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkIns := &fakeCheckInRepo{err: tt.err}
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{CheckIn: config.CheckIn{RadiusMeters: 200, DailyLimit: 20}},
+				UseCase: &usecase.UseCase{
+					BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: {
+						ID:       testBusinessID,
+						Status:   entity.ContentStatusPublished,
+						Location: location,
+					}}},
+					CheckInRepo: checkIns,
+				},
+			}
+
+			ctx, w := newPrincipalContext(user, http.MethodPost, `{"location": {"latitude": 41.3112, "longitude": 69.2797}}`)
+			ctx.Params = gin.Params{{Key: "id", Value: testBusinessID}}
+			h.CheckIn(ctx)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if checkIns.dailyLimit != 20 {
+				t.Fatalf("daily limit passed to the repo = %d, want 20", checkIns.dailyLimit)
+			}
+		})
+	}
+}
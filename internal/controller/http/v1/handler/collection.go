@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// getVisibleCollection loads the collection from the path. Private collections are only visible to their owner and admins.
+func (h *Handler) getVisibleCollection(ctx *gin.Context) (entity.Collection, bool) {
+	collection, err := h.UseCase.CollectionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting collection") {
+		return collection, false
+	}
+
+	principal := GetPrincipal(ctx)
+	if !collection.IsPublic && collection.UserID != principal.UserID && !principal.IsAdmin() {
+		h.ReturnError(ctx, config.ErrorNotFound, "Collection not found", http.StatusNotFound)
+		return collection, false
+	}
+
+	return collection, true
+}
+
+// setBookmarked fills Business.Bookmarked for the businesses the current principal saved in a collection.
+func (h *Handler) setBookmarked(ctx *gin.Context, businesses []entity.Business) {
+	principal := GetPrincipal(ctx)
+	if !principal.IsAuthenticated() || len(businesses) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(businesses))
+	for _, business := range businesses {
+		ids = append(ids, business.ID)
+	}
+
+	bookmarked, err := h.UseCase.CollectionItemRepo.GetBookmarked(ctx, principal.UserID, ids)
+	if err != nil {
+		h.Logger.Error(err, "Error getting bookmarks")
+		return
+	}
+
+	saved := make(map[string]bool, len(bookmarked))
+	for _, id := range bookmarked {
+		saved[id] = true
+	}
+
+	for i := range businesses {
+		businesses[i].Bookmarked = saved[businesses[i].ID]
+	}
+}
+
+// CreateCollection godoc
+// @Router /user/collections [post]
+// @Summary Create a collection
+// @Description Create a named list of businesses, private unless is_public is set
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param collection body entity.CreateCollectionRequest true "Collection object"
+// @Success 201 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateCollection(ctx *gin.Context) {
+	var body entity.CreateCollectionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	collection, err := h.UseCase.CollectionRepo.Create(ctx, entity.Collection{
+		UserID:      GetPrincipal(ctx).UserID,
+		Name:        body.Name,
+		Description: body.Description,
+		IsPublic:    body.IsPublic,
+	})
+	if h.HandleDbError(ctx, err, "Error creating collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, collection)
+}
+
+// GetCollection godoc
+// @Router /user/collections/{id} [get]
+// @Summary Get a collection by ID
+// @Description Get a public collection, or one of your own
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCollection(ctx *gin.Context) {
+	collection, ok := h.getVisibleCollection(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// GetCollections godoc
+// @Router /user/collections/list [get]
+// @Summary Get a list of collections
+// @Description Get the collections of a user. Without user_id, get your own. Only public collections of other users are listed.
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param user_id query string false "user_id"
+// @Success 200 {object} entity.CollectionList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCollections(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	principal := GetPrincipal(ctx)
+	userID := ctx.DefaultQuery("user_id", principal.UserID)
+	if userID == "" {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "user_id",
+			Code:    config.ErrorRequiredField,
+			Message: "is required",
+		})
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "user_id",
+		Type:   "eq",
+		Value:  userID,
+	})
+
+	if userID != principal.UserID && !principal.IsAdmin() {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "is_public",
+			Type:   "eq",
+			Value:  "true",
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	collections, err := h.UseCase.CollectionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting collections") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collections)
+}
+
+// UpdateCollection godoc
+// @Router /user/collections [put]
+// @Summary Update a collection
+// @Description Rename your collection, or make it public or private
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param collection body entity.UpdateCollectionRequest true "Collection object"
+// @Success 200 {object} entity.Collection
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateCollection(ctx *gin.Context) {
+	var body entity.UpdateCollectionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceCollection, body.ID) {
+		return
+	}
+
+	collection, err := h.UseCase.CollectionRepo.Update(ctx, entity.Collection{
+		ID:          body.ID,
+		Name:        body.Name,
+		Description: body.Description,
+		IsPublic:    body.IsPublic,
+	})
+	if h.HandleDbError(ctx, err, "Error updating collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, collection)
+}
+
+// DeleteCollection godoc
+// @Router /user/collections/{id} [delete]
+// @Summary Delete a collection
+// @Description Delete your collection and everything saved in it
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteCollection(ctx *gin.Context) {
+	var req entity.Id
+
+	req.ID = ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceCollection, req.ID) {
+		return
+	}
+
+	err := h.UseCase.CollectionRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting collection") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Collection deleted successfully",
+	})
+}
+
+// AddCollectionItem godoc
+// @Router /user/collections/{id}/items [post]
+// @Summary Save a business in a collection
+// @Description Save a business in your collection, with an optional note
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param item body entity.CollectionItemRequest true "Item"
+// @Success 201 {object} entity.CollectionItem
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) AddCollectionItem(ctx *gin.Context) {
+	var body entity.CollectionItemRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	collection, err := h.UseCase.CollectionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting collection") {
+		return
+	}
+
+	if collection.UserID != GetPrincipal(ctx).UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "You can only save businesses in your own collections", http.StatusForbidden)
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	if business.Status != entity.ContentStatusPublished {
+		h.ReturnError(ctx, config.ErrorNotFound, "Business not found", http.StatusNotFound)
+		return
+	}
+
+	item, err := h.UseCase.CollectionItemRepo.Create(ctx, entity.CollectionItem{
+		CollectionID: collection.ID,
+		BusinessID:   business.ID,
+		UserID:       collection.UserID,
+		Note:         body.Note,
+	})
+	if h.HandleDbError(ctx, err, "Error saving business") {
+		return
+	}
+
+	item.Business = business
+	item.Business.Bookmarked = true
+
+	ctx.JSON(http.StatusCreated, item)
+}
+
+// GetCollectionItems godoc
+// @Router /user/collections/{id}/items [get]
+// @Summary Get the businesses in a collection
+// @Description Get the businesses saved in a public collection or one of your own, last saved first
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.CollectionItemList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCollectionItems(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	collection, ok := h.getVisibleCollection(ctx)
+	if !ok {
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters,
+		entity.Filter{
+			Column: "ci.collection_id",
+			Type:   "eq",
+			Value:  collection.ID,
+		},
+		entity.Filter{
+			Column: "b.status",
+			Type:   "eq",
+			Value:  entity.ContentStatusPublished,
+		},
+	)
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "ci.created_at",
+		Order:  "desc",
+	})
+
+	items, err := h.UseCase.CollectionItemRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting collection items") {
+		return
+	}
+
+	businesses := make([]entity.Business, 0, len(items.Items))
+	for _, item := range items.Items {
+		businesses = append(businesses, item.Business)
+	}
+
+	h.setBookmarked(ctx, businesses)
+
+	for i := range items.Items {
+		items.Items[i].Business.Bookmarked = businesses[i].Bookmarked
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+// RemoveCollectionItem godoc
+// @Router /user/collections/{id}/items/{business_id} [delete]
+// @Summary Remove a business from a collection
+// @Description Remove a business from your collection
+// @Security BearerAuth
+// @Tags collection
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Collection ID"
+// @Param business_id path string true "Business ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) RemoveCollectionItem(ctx *gin.Context) {
+	collectionID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceCollection, collectionID) {
+		return
+	}
+
+	result, err := h.UseCase.CollectionItemRepo.Delete(ctx, entity.CollectionItem{
+		CollectionID: collectionID,
+		BusinessID:   ctx.Param("business_id"),
+	})
+	if h.HandleDbError(ctx, err, "Error removing business") {
+		return
+	}
+
+	if result.RowsEffected == 0 {
+		h.ReturnError(ctx, config.ErrorNotFound, "Business is not in this collection", http.StatusNotFound)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Business removed from collection successfully",
+	})
+}
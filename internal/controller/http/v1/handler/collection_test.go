@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+const testCollectionID = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+
+type fakeCollectionRepo struct {
+	usecase.CollectionRepoI
+	collection entity.Collection
+}
+
+func (r *fakeCollectionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Collection, error) {
+	if req.ID != r.collection.ID {
+		return entity.Collection{}, pgx.ErrNoRows
+	}
+	return r.collection, nil
+}
+
+func TestGetVisibleCollection(t *testing.T) {
+	var (
+		anonymous = entity.Principal{}
+		owner     = entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}
+		other     = entity.Principal{UserID: spoofedUserID, UserRole: entity.UserRoleUser}
+		admin     = entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}
+	)
+
+	tests := []struct {
+		name      string
+		principal entity.Principal
+		id        string
+		isPublic  bool
+		visible   bool
+		status    int
+	}{
+		{"public to anonymous", anonymous, testCollectionID, true, true, http.StatusOK},
+		{"public to another user", other, testCollectionID, true, true, http.StatusOK},
+		{"private to anonymous", anonymous, testCollectionID, false, false, http.StatusNotFound},
+		{"private to another user", other, testCollectionID, false, false, http.StatusNotFound},
+		{"private to its owner", owner, testCollectionID, false, true, http.StatusOK},
+		{"private to an admin", admin, testCollectionID, false, true, http.StatusOK},
+		{"missing", owner, spoofedUserID, true, false, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{CollectionRepo: &fakeCollectionRepo{collection: entity.Collection{
+					ID:       testCollectionID,
+					UserID:   testUserID,
+					IsPublic: tt.isPublic,
+				}}},
+			}
+
+			ctx, w := newPrincipalContext(tt.principal, http.MethodGet, "")
+			ctx.Params = gin.Params{{Key: "id", Value: tt.id}}
+
+			_, visible := h.getVisibleCollection(ctx)
+			if visible != tt.visible {
+				t.Fatalf("getVisibleCollection() = %v, want %v", visible, tt.visible)
+			}
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// findDuplicates returns the published businesses within config.DuplicateRadius of the location
+// whose name is similar to the given one, leaving out the business exceptID.
+func (h *Handler) findDuplicates(ctx *gin.Context, name string, location entity.Location, exceptID string) ([]entity.DuplicateCandidate, error) {
+	southWest, northEast := location.BoundingBox(config.DuplicateRadius)
+
+	// one extra so that leaving out exceptID still fills the limit
+	items, err := h.UseCase.BusinessRepo.FindSimilar(ctx, name, southWest, northEast,
+		config.DuplicateNameSimilarity, config.MaxDuplicateCandidates+1)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []entity.DuplicateCandidate{}
+	for _, item := range items {
+		item.DistanceMeters = location.DistanceTo(item.Location)
+		// the bounding box corners are further away than the radius
+		if item.ID == exceptID || item.DistanceMeters > config.DuplicateRadius {
+			continue
+		}
+
+		candidates = append(candidates, item)
+	}
+
+	if len(candidates) > config.MaxDuplicateCandidates {
+		candidates = candidates[:config.MaxDuplicateCandidates]
+	}
+
+	return candidates, nil
+}
+
+// GetBusinessDuplicates godoc
+// @Router /admin/business/{id}/duplicates [get]
+// @Summary Get possible duplicates of a business
+// @Description Get the published businesses nearby whose name is similar to the business, most similar first.
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Success 200 {object} []entity.DuplicateCandidate
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessDuplicates(ctx *gin.Context) {
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	candidates, err := h.findDuplicates(ctx, business.Name, business.Location, business.ID)
+	if h.HandleDbError(ctx, err, "Error looking for duplicate businesses") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, candidates)
+}
+
+// MergeBusinesses godoc
+// @Router /admin/business/merge [post]
+// @Summary Merge a duplicate business into another one
+// @Description Move the reviews, photos, bookmarks, check-ins and questions of the source business to the target.
+// @Description Reviews and bookmarks the target already has from the same user or collection are dropped and counted.
+// @Description The source is hidden and GET /business/{id} on it redirects to the target from then on.
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param request body entity.MergeBusinessRequest true "Merge request"
+// @Success 200 {object} entity.MergeBusinessResult
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) MergeBusinesses(ctx *gin.Context) {
+	var body entity.MergeBusinessRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	source, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.SourceID})
+	if h.HandleDbError(ctx, err, "Error getting source business") {
+		return
+	}
+
+	target, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.TargetID})
+	if h.HandleDbError(ctx, err, "Error getting target business") {
+		return
+	}
+
+	if source.MergedInto != "" {
+		h.ReturnError(ctx, config.ErrorConflict, "Source business was already merged into "+source.MergedInto, http.StatusConflict)
+		return
+	}
+
+	if target.MergedInto != "" {
+		h.ReturnError(ctx, config.ErrorConflict, "Target business was merged into "+target.MergedInto+", merge into that one instead", http.StatusConflict)
+		return
+	}
+
+	result, err := h.UseCase.BusinessRepo.Merge(ctx, source.ID, target.ID)
+	if h.HandleDbError(ctx, err, "Error merging businesses") {
+		return
+	}
+
+	after := source
+	after.MergedInto = target.ID
+	after.Status = entity.ContentStatusHidden
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionBusinessMerge,
+		TargetType: entity.AuditTargetBusiness,
+		TargetID:   source.ID,
+	}, source, after)
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+type fakeAuditLogRepo struct {
+	usecase.AuditLogRepoI
+	entries []entity.AuditLog
+}
+
+func (r *fakeAuditLogRepo) Create(ctx context.Context, req entity.AuditLog) (entity.AuditLog, error) {
+	r.entries = append(r.entries, req)
+	return req, nil
+}
+
+func TestFindDuplicates(t *testing.T) {
+	location := entity.Location{Latitude: 41.3111, Longitude: 69.2797}
+
+	// about 50 meters north
+	near := func(id string) entity.DuplicateCandidate {
+		return entity.DuplicateCandidate{ID: id, Location: entity.Location{Latitude: 41.3115, Longitude: 69.2797}}
+	}
+	// in the corner of the bounding box, about 250 meters away
+	corner := entity.DuplicateCandidate{ID: "corner", Location: entity.Location{Latitude: 41.3126, Longitude: 69.2819}}
+
+	many := func(n int) []entity.DuplicateCandidate {
+		items := make([]entity.DuplicateCandidate, n)
+		for i := range items {
+			items[i] = near(fmt.Sprintf("near-%d", i))
+		}
+		return items
+	}
+
+	tests := []struct {
+		name     string
+		similar  []entity.DuplicateCandidate
+		exceptID string
+		want     []string
+	}{
+		{"nothing similar", nil, "", []string{}},
+		{"nearby", []entity.DuplicateCandidate{near("near-0")}, "", []string{"near-0"}},
+		{"outside the radius", []entity.DuplicateCandidate{corner, near("near-0")}, "", []string{"near-0"}},
+		{"leaves out the business itself", append([]entity.DuplicateCandidate{near("self")}, many(config.MaxDuplicateCandidates+1)...), "self",
+			[]string{"near-0", "near-1", "near-2", "near-3", "near-4"}},
+		{"at most the limit", many(config.MaxDuplicateCandidates + 2), "",
+			[]string{"near-0", "near-1", "near-2", "near-3", "near-4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				Logger:  logger.New("error"),
+				Config:  &config.Config{},
+				UseCase: &usecase.UseCase{BusinessRepo: &fakeBusinessRepo{similar: tt.similar}},
+			}
+
+			ctx, _ := newPrincipalContext(entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, http.MethodGet, "")
+
+			candidates, err := h.findDuplicates(ctx, "Cafe Plov", location, tt.exceptID)
+			if err != nil {
+				t.Fatalf("findDuplicates() error: %v", err)
+			}
+
+			if len(candidates) != len(tt.want) {
+				t.Fatalf("got %d candidates %+v, want %v", len(candidates), candidates, tt.want)
+			}
+			for i, candidate := range candidates {
+				if candidate.ID != tt.want[i] {
+					t.Fatalf("candidate %d = %s, want %s", i, candidate.ID, tt.want[i])
+				}
+				if candidate.DistanceMeters <= 0 || candidate.DistanceMeters > config.DuplicateRadius {
+					t.Fatalf("candidate %s is %.0f meters away", candidate.ID, candidate.DistanceMeters)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeBusinesses_Preconditions(t *testing.T) {
+	const otherID = "13131313-1313-1313-1313-131313131313"
+
+	admin := entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin, SessionID: testSessionID}
+
+	tests := []struct {
+		name       string
+		sourceID   string
+		targetID   string
+		businesses map[string]entity.Business
+		status     int
+	}{
+		{"merges", testBusinessID, testTargetID, map[string]entity.Business{
+			testBusinessID: {ID: testBusinessID},
+			testTargetID:   {ID: testTargetID},
+		}, http.StatusOK},
+		{"into itself", testBusinessID, testBusinessID, map[string]entity.Business{
+			testBusinessID: {ID: testBusinessID},
+		}, http.StatusBadRequest},
+		{"missing target", testBusinessID, testTargetID, map[string]entity.Business{
+			testBusinessID: {ID: testBusinessID},
+		}, http.StatusNotFound},
+		{"source already merged", testBusinessID, testTargetID, map[string]entity.Business{
+			testBusinessID: {ID: testBusinessID, MergedInto: otherID},
+			testTargetID:   {ID: testTargetID},
+		}, http.StatusConflict},
+		{"target merged elsewhere", testBusinessID, testTargetID, map[string]entity.Business{
+			testBusinessID: {ID: testBusinessID},
+			testTargetID:   {ID: testTargetID, MergedInto: otherID},
+		}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			businesses := &fakeBusinessRepo{businesses: tt.businesses}
+			audit := &fakeAuditLogRepo{}
+			h := &Handler{
+				Logger:  logger.New("error"),
+				Config:  &config.Config{},
+				UseCase: &usecase.UseCase{BusinessRepo: businesses, AuditLogRepo: audit},
+			}
+
+			ctx, w := newPrincipalContext(admin, http.MethodPost,
+				`{"source_id": "`+tt.sourceID+`", "target_id": "`+tt.targetID+`"}`)
+			h.MergeBusinesses(ctx)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+
+			merged := len(businesses.merges) == 1 && businesses.merges[0] == [2]string{tt.sourceID, tt.targetID}
+			if merged != (tt.status == http.StatusOK) {
+				t.Fatalf("merges = %v after a %d", businesses.merges, w.Code)
+			}
+			if merged && len(audit.entries) != 1 {
+				t.Fatalf("expected the merge to be audited, got %d entries", len(audit.entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+const (
+	feedDefaultLimit = 20
+	feedMaxLimit     = 50 // also the size of the cached first page
+)
+
+func feedCacheKey(userID string) string {
+	return fmt.Sprintf("feed-%s", userID)
+}
+
+// encodeFeedCursor points a cursor at a feed item. The next page starts after it.
+func encodeFeedCursor(item entity.FeedItem) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(item.CreatedAt + "|" + item.ID))
+}
+
+func decodeFeedCursor(cursor string) (createdAt, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", err
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return "", "", errors.New("malformed cursor")
+	}
+
+	if _, err = time.Parse(time.RFC3339, parts[0]); err != nil {
+		return "", "", err
+	}
+
+	if _, err = uuid.Parse(parts[1]); err != nil {
+		return "", "", err
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// getFeedPage reads a page of the feed. The first page comes from the Redis timeline cache when it is enabled;
+// it holds feedMaxLimit items and is rebuilt from Postgres on a miss.
+func (h *Handler) getFeedPage(ctx *gin.Context, req entity.FeedRequest) ([]entity.FeedItem, error) {
+	if req.CursorID != "" || h.Config.Feed.CacheTTL <= 0 {
+		return h.UseCase.FollowRepo.GetFeed(ctx, req)
+	}
+
+	var items []entity.FeedItem
+
+	key := feedCacheKey(req.UserID)
+
+	value, err := h.Redis.Get(ctx, key)
+	if err != nil || json.Unmarshal([]byte(value), &items) != nil {
+		limit := req.Limit
+		req.Limit = feedMaxLimit
+
+		items, err = h.UseCase.FollowRepo.GetFeed(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := json.Marshal(items)
+		if err == nil {
+			err = h.Redis.Set(ctx, key, string(body), h.Config.Feed.CacheTTL)
+		}
+		if err != nil {
+			h.Logger.Error(err, "Error caching feed")
+		}
+
+		req.Limit = limit
+	}
+
+	if len(items) > req.Limit {
+		items = items[:req.Limit]
+	}
+
+	return items, nil
+}
+
+// invalidateFeed drops the cached first page of a user's feed, e.g. after they follow someone.
+func (h *Handler) invalidateFeed(ctx *gin.Context, userID string) {
+	if h.Config.Feed.CacheTTL <= 0 {
+		return
+	}
+
+	err := h.Redis.Del(ctx, feedCacheKey(userID))
+	if err != nil {
+		h.Logger.Error(err, "Error invalidating cached feed")
+	}
+}
+
+// GetFeed godoc
+// @Router /feed [get]
+// @Summary Get your activity feed
+// @Description Get the reviews, check-ins and photos of the users you follow, newest first.
+// @Description Pass next_cursor of a page as cursor to get the next one.
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param limit query number false "limit, at most 50"
+// @Param cursor query string false "cursor"
+// @Success 200 {object} entity.Feed
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetFeed(ctx *gin.Context) {
+	req := entity.FeedRequest{
+		UserID: GetPrincipal(ctx).UserID,
+		Limit:  feedDefaultLimit,
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > feedMaxLimit {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "limit",
+				Code:    config.ErrorInvalidValue,
+				Message: fmt.Sprintf("must be between 1 and %d", feedMaxLimit),
+			})
+			return
+		}
+		req.Limit = n
+	}
+
+	if cursor := ctx.Query("cursor"); cursor != "" {
+		var err error
+
+		req.CursorCreatedAt, req.CursorID, err = decodeFeedCursor(cursor)
+		if err != nil {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "cursor",
+				Code:    config.ErrorInvalidValue,
+				Message: "is invalid",
+			})
+			return
+		}
+	}
+
+	items, err := h.getFeedPage(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting feed") {
+		return
+	}
+
+	feed := entity.Feed{Items: items}
+	if feed.Items == nil {
+		feed.Items = []entity.FeedItem{}
+	}
+
+	if len(items) == req.Limit {
+		feed.NextCursor = encodeFeedCursor(items[len(items)-1])
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+type fakeFollowRepo struct {
+	usecase.FollowRepoI
+	items []entity.FeedItem
+	reads int
+}
+
+func (r *fakeFollowRepo) GetFeed(ctx context.Context, req entity.FeedRequest) ([]entity.FeedItem, error) {
+	r.reads++
+	if len(r.items) > req.Limit {
+		return r.items[:req.Limit], nil
+	}
+	return r.items, nil
+}
+
+func TestFeedCursor_RoundTrip(t *testing.T) {
+	item := entity.FeedItem{ID: testUserID, CreatedAt: "2024-05-01T10:00:00Z"}
+
+	createdAt, id, err := decodeFeedCursor(encodeFeedCursor(item))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if createdAt != item.CreatedAt || id != item.ID {
+		t.Fatalf("unexpected cursor %s %s", createdAt, id)
+	}
+
+	for _, cursor := range []string{"not base64!", "bm8tc2VwYXJhdG9y", encodeFeedCursor(entity.FeedItem{ID: "1' OR 1=1", CreatedAt: item.CreatedAt})} {
+		if _, _, err = decodeFeedCursor(cursor); err == nil {
+			t.Fatalf("expected %q to be rejected", cursor)
+		}
+	}
+}
+
+func TestGetFeedPage_CachesFirstPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &fakeFollowRepo{}
+	for i := 0; i < 30; i++ {
+		repo.items = append(repo.items, entity.FeedItem{ID: testUserID, Type: entity.FeedItemReview})
+	}
+
+	h := &Handler{
+		Logger:  logger.New("error"),
+		Config:  &config.Config{Feed: config.Feed{CacheTTL: 60}},
+		UseCase: &usecase.UseCase{FollowRepo: repo},
+		Redis:   &fakeRedis{items: map[string]string{}},
+	}
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	for _, limit := range []int{10, 25} {
+		items, err := h.getFeedPage(ctx, entity.FeedRequest{UserID: testUserID, Limit: limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != limit {
+			t.Fatalf("expected %d items, got %d", limit, len(items))
+		}
+	}
+
+	if repo.reads != 1 {
+		t.Fatalf("expected the first page to be read once, got %d reads", repo.reads)
+	}
+
+	h.invalidateFeed(ctx, testUserID)
+
+	_, err := h.getFeedPage(ctx, entity.FeedRequest{UserID: testUserID, Limit: 10, CursorCreatedAt: "2024-05-01T10:00:00Z", CursorID: testUserID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = h.getFeedPage(ctx, entity.FeedRequest{UserID: testUserID, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if repo.reads != 3 {
+		t.Fatalf("expected later pages and invalidated feeds to be read from Postgres, got %d reads", repo.reads)
+	}
+}
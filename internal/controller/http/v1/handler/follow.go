@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// FollowUser godoc
+// @Router /user/{id}/follow [post]
+// @Summary Follow a user
+// @Description Follow a user to see their reviews, check-ins and photos in your feed
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) FollowUser(ctx *gin.Context) {
+	principal := GetPrincipal(ctx)
+	followeeID := ctx.Param("id")
+
+	if followeeID == principal.UserID {
+		h.ReturnError(ctx, config.ErrorBadRequest, "You can't follow yourself", http.StatusBadRequest)
+		return
+	}
+
+	followee, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: followeeID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	if followee.Status != entity.UserStatusActive {
+		h.ReturnError(ctx, config.ErrorNotFound, "User not found", http.StatusNotFound)
+		return
+	}
+
+	err = h.UseCase.FollowRepo.Create(ctx, entity.Follow{
+		FollowerID: principal.UserID,
+		FolloweeID: followee.ID,
+	})
+	if h.HandleDbError(ctx, err, "Error following user") {
+		return
+	}
+
+	h.invalidateFeed(ctx, principal.UserID)
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User followed successfully",
+	})
+}
+
+// UnfollowUser godoc
+// @Router /user/{id}/follow [delete]
+// @Summary Unfollow a user
+// @Description Stop following a user
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UnfollowUser(ctx *gin.Context) {
+	principal := GetPrincipal(ctx)
+
+	result, err := h.UseCase.FollowRepo.Delete(ctx, entity.Follow{
+		FollowerID: principal.UserID,
+		FolloweeID: ctx.Param("id"),
+	})
+	if h.HandleDbError(ctx, err, "Error unfollowing user") {
+		return
+	}
+
+	if result.RowsEffected == 0 {
+		h.ReturnError(ctx, config.ErrorNotFound, "You don't follow this user", http.StatusNotFound)
+		return
+	}
+
+	h.invalidateFeed(ctx, principal.UserID)
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "User unfollowed successfully",
+	})
+}
+
+// followListRequest reads the page of a follower or following list of the user in the path.
+func followListRequest(ctx *gin.Context, column string) entity.GetListFilter {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: column,
+		Type:   "eq",
+		Value:  ctx.Param("id"),
+	})
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "f.created_at",
+		Order:  "desc",
+	})
+
+	return req
+}
+
+// GetFollowers godoc
+// @Router /user/{id}/followers [get]
+// @Summary Get the followers of a user
+// @Description Get the users following a user, most recent first
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.FollowUserList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetFollowers(ctx *gin.Context) {
+	users, err := h.UseCase.FollowRepo.GetFollowers(ctx, followListRequest(ctx, "f.followee_id"))
+	if h.HandleDbError(ctx, err, "Error getting followers") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+// GetFollowing godoc
+// @Router /user/{id}/following [get]
+// @Summary Get the users a user follows
+// @Description Get the users a user follows, most recent first
+// @Security BearerAuth
+// @Tags follow
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.FollowUserList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetFollowing(ctx *gin.Context) {
+	users, err := h.UseCase.FollowRepo.GetFollowing(ctx, followListRequest(ctx, "f.follower_id"))
+	if h.HandleDbError(ctx, err, "Error getting followed users") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
@@ -1,24 +1,37 @@
 package handler
 
 import (
+	"github.com/casbin/casbin"
 	rediscache "github.com/golanguzb70/redis-cache"
 	"yalp_ulab/config"
 	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/contentfilter"
+	"yalp_ulab/pkg/geoip"
 	"yalp_ulab/pkg/logger"
 )
 
 type Handler struct {
-	Logger  *logger.Logger
-	Config  *config.Config
-	UseCase *usecase.UseCase
-	Redis   rediscache.RedisCache
+	Logger   *logger.Logger
+	Config   *config.Config
+	UseCase  *usecase.UseCase
+	Redis    rediscache.RedisCache
+	GeoIP    *geoip.GeoIP
+	Enforcer *casbin.SyncedEnforcer
+
+	ReviewFilter   *contentfilter.Pipeline
+	BusinessFilter *contentfilter.Pipeline
 }
 
-func NewHandler(l *logger.Logger, c *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache) *Handler {
+func NewHandler(l *logger.Logger, c *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache, geo *geoip.GeoIP, enforcer *casbin.SyncedEnforcer) *Handler {
 	return &Handler{
-		Logger:  l,
-		Config:  c,
-		UseCase: useCase,
-		Redis:   redis,
+		Logger:   l,
+		Config:   c,
+		UseCase:  useCase,
+		Redis:    redis,
+		GeoIP:    geo,
+		Enforcer: enforcer,
+
+		ReviewFilter:   newReviewFilter(c, useCase),
+		BusinessFilter: newBusinessFilter(c, useCase),
 	}
 }
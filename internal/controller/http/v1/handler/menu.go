@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// menuPhotos returns the URLs of the published photos of a business that the menu items reference, by photo ID.
+func (h *Handler) menuPhotos(ctx *gin.Context, businessID string, sections []entity.MenuSection) (map[string]string, error) {
+	var ids []string
+	seen := map[string]bool{}
+
+	for _, section := range sections {
+		for _, item := range section.Items {
+			if item.PhotoID != "" && !seen[item.PhotoID] {
+				seen[item.PhotoID] = true
+				ids = append(ids, item.PhotoID)
+			}
+		}
+	}
+
+	urls := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return urls, nil
+	}
+
+	photos, err := h.UseCase.PhotoRepo.GetList(ctx, entity.GetListFilter{
+		Limit: len(ids),
+		Filters: []entity.Filter{
+			{Column: "id", Type: "in", Value: strings.Join(ids, ",")},
+			{Column: "business_id", Type: "eq", Value: businessID},
+			{Column: "status", Type: "eq", Value: entity.ContentStatusPublished},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, photo := range photos.Items {
+		urls[photo.ID] = photo.URL
+	}
+
+	return urls, nil
+}
+
+// setMenuPhotos fills MenuItem.PhotoURL. Items whose photo was hidden or deleted since are shown without one.
+func (h *Handler) setMenuPhotos(ctx *gin.Context, menu *entity.Menu) error {
+	urls, err := h.menuPhotos(ctx, menu.BusinessID, menu.Sections)
+	if err != nil {
+		return err
+	}
+
+	for i := range menu.Sections {
+		for j := range menu.Sections[i].Items {
+			item := &menu.Sections[i].Items[j]
+			item.PhotoURL = urls[item.PhotoID]
+		}
+	}
+
+	return nil
+}
+
+// prepareMenu generates missing section and item IDs and checks that the photos the items reference belong to the business.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) prepareMenu(ctx *gin.Context, menu *entity.Menu) bool {
+	if menu.Sections == nil {
+		menu.Sections = []entity.MenuSection{}
+	}
+
+	urls, err := h.menuPhotos(ctx, menu.BusinessID, menu.Sections)
+	if h.HandleDbError(ctx, err, "Error getting photos") {
+		return false
+	}
+
+	var fields []entity.FieldError
+
+	for i := range menu.Sections {
+		section := &menu.Sections[i]
+		if section.ID == "" {
+			section.ID = uuid.NewString()
+		}
+		if section.Items == nil {
+			section.Items = []entity.MenuItem{}
+		}
+
+		for j := range section.Items {
+			item := &section.Items[j]
+			if item.ID == "" {
+				item.ID = uuid.NewString()
+			}
+			if item.DietaryTags == nil {
+				item.DietaryTags = []string{}
+			}
+
+			item.PhotoURL = ""
+			if item.PhotoID != "" {
+				url, ok := urls[item.PhotoID]
+				if !ok {
+					fields = append(fields, entity.FieldError{
+						Field:   fmt.Sprintf("sections[%d].items[%d].photo_id", i, j),
+						Code:    config.ErrorInvalidValue,
+						Message: "must be a published photo of this business",
+					})
+				}
+				item.PhotoURL = url
+			}
+		}
+	}
+
+	if len(fields) != 0 {
+		h.ReturnValidationError(ctx, fields...)
+		return false
+	}
+
+	return true
+}
+
+// CreateMenu godoc
+// @Router /business/{id}/menus [post]
+// @Summary Add a menu or service catalog to your business
+// @Description Add a menu with its sections and items. Prices are in minor units of the menu currency, e.g. 1250 is 12.50 USD.
+// @Security BearerAuth
+// @Tags menu
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param menu body entity.CreateMenuRequest true "Menu object"
+// @Success 201 {object} entity.Menu
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateMenu(ctx *gin.Context) {
+	var body entity.CreateMenuRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	menus, err := h.UseCase.MenuRepo.GetList(ctx, entity.GetListFilter{
+		Limit:   1,
+		Filters: []entity.Filter{{Column: "business_id", Type: "eq", Value: businessID}},
+	})
+	if h.HandleDbError(ctx, err, "Error counting menus") {
+		return
+	}
+
+	if menus.Count >= config.MaxMenusPerBusiness {
+		h.ReturnError(ctx, config.ErrorBadRequest,
+			fmt.Sprintf("A business can have at most %d menus", config.MaxMenusPerBusiness), http.StatusBadRequest)
+		return
+	}
+
+	menu := entity.Menu{
+		BusinessID:  businessID,
+		Kind:        body.Kind,
+		Name:        body.Name,
+		Description: body.Description,
+		Currency:    body.Currency,
+		Position:    body.Position,
+		Sections:    body.Sections,
+	}
+
+	if !h.prepareMenu(ctx, &menu) {
+		return
+	}
+
+	created, err := h.UseCase.MenuRepo.Create(ctx, menu)
+	if h.HandleDbError(ctx, err, "Error creating menu") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, created)
+}
+
+// GetMenus godoc
+// @Router /business/{id}/menus [get]
+// @Summary Get the menus of a business
+// @Description Get the menus and service catalogs of a business with their sections and items, by position
+// @Security BearerAuth
+// @Tags menu
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Success 200 {object} entity.MenuList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetMenus(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	business, ok := h.getVisibleBusiness(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	req.Limit = config.MaxMenusPerBusiness
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "business_id",
+		Type:   "eq",
+		Value:  business.ID,
+	})
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{
+			Column: "position",
+			Order:  "asc",
+		},
+		entity.OrderBy{
+			Column: "created_at",
+			Order:  "asc",
+		},
+	)
+
+	menus, err := h.UseCase.MenuRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting menus") {
+		return
+	}
+
+	for i := range menus.Items {
+		err = h.setMenuPhotos(ctx, &menus.Items[i])
+		if h.HandleDbError(ctx, err, "Error getting photos") {
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, menus)
+}
+
+// GetMenu godoc
+// @Router /business/menus/{id} [get]
+// @Summary Get a menu by ID
+// @Description Get a menu or service catalog with its sections and items
+// @Security BearerAuth
+// @Tags menu
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Menu ID"
+// @Success 200 {object} entity.Menu
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetMenu(ctx *gin.Context) {
+	menu, err := h.UseCase.MenuRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting menu") {
+		return
+	}
+
+	if _, ok := h.getVisibleBusiness(ctx, menu.BusinessID); !ok {
+		return
+	}
+
+	err = h.setMenuPhotos(ctx, &menu)
+	if h.HandleDbError(ctx, err, "Error getting photos") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, menu)
+}
+
+// UpdateMenu godoc
+// @Router /business/menus [put]
+// @Summary Update a menu of your business
+// @Description Replace a menu with its sections and items. Sections and items sent without an ID are added, the ones left out are removed.
+// @Security BearerAuth
+// @Tags menu
+// @Accept  json
+// @Produce  json
+// @Param menu body entity.UpdateMenuRequest true "Menu object"
+// @Success 200 {object} entity.Menu
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateMenu(ctx *gin.Context) {
+	var body entity.UpdateMenuRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	menu, err := h.UseCase.MenuRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting menu") {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, menu.BusinessID) {
+		return
+	}
+
+	menu.Kind = body.Kind
+	menu.Name = body.Name
+	menu.Description = body.Description
+	menu.Currency = body.Currency
+	menu.Position = body.Position
+	menu.Sections = body.Sections
+
+	if !h.prepareMenu(ctx, &menu) {
+		return
+	}
+
+	updated, err := h.UseCase.MenuRepo.Update(ctx, menu)
+	if h.HandleDbError(ctx, err, "Error updating menu") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, updated)
+}
+
+// DeleteMenu godoc
+// @Router /business/menus/{id} [delete]
+// @Summary Delete a menu of your business
+// @Description Delete a menu with all its sections and items
+// @Security BearerAuth
+// @Tags menu
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Menu ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteMenu(ctx *gin.Context) {
+	menu, err := h.UseCase.MenuRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting menu") {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, menu.BusinessID) {
+		return
+	}
+
+	err = h.UseCase.MenuRepo.Delete(ctx, entity.Id{ID: menu.ID})
+	if h.HandleDbError(ctx, err, "Error deleting menu") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Menu deleted successfully",
+	})
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+const (
+	testPhotoID       = "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+	testHiddenPhotoID = "cccccccc-cccc-cccc-cccc-cccccccccccc"
+	testOtherPhotoID  = "dddddddd-dddd-dddd-dddd-dddddddddddd"
+)
+
+// fakePhotoRepo lists the photos matching the id, business_id and status filters menuPhotos uses.
+type fakePhotoRepo struct {
+	usecase.PhotoRepoI
+	photos []entity.Photo
+}
+
+func (r *fakePhotoRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.PhotoList, error) {
+	var list entity.PhotoList
+
+	for _, photo := range r.photos {
+		match := true
+		for _, filter := range req.Filters {
+			switch filter.Column {
+			case "id":
+				match = match && strings.Contains(filter.Value, photo.ID)
+			case "business_id":
+				match = match && filter.Value == photo.BusinessID
+			case "status":
+				match = match && filter.Value == photo.Status
+			}
+		}
+
+		if match {
+			list.Items = append(list.Items, photo)
+			list.Count++
+		}
+	}
+
+	return list, nil
+}
+
+func TestPrepareMenu_PhotosMustBePublishedPhotosOfTheBusiness(t *testing.T) {
+	photos := &fakePhotoRepo{photos: []entity.Photo{
+		{ID: testPhotoID, BusinessID: testBusinessID, URL: "https://cdn.example.com/dish.jpg", Status: entity.ContentStatusPublished},
+		{ID: testHiddenPhotoID, BusinessID: testBusinessID, URL: "https://cdn.example.com/hidden.jpg", Status: entity.ContentStatusHidden},
+		{ID: testOtherPhotoID, BusinessID: testTargetID, URL: "https://cdn.example.com/other.jpg", Status: entity.ContentStatusPublished},
+	}}
+
+	tests := []struct {
+		name    string
+		photoID string
+		ok      bool
+		url     string
+	}{
+		{"no photo", "", true, ""},
+		{"published photo of the business", testPhotoID, true, "https://cdn.example.com/dish.jpg"},
+		{"hidden photo of the business", testHiddenPhotoID, false, ""},
+		{"photo of another business", testOtherPhotoID, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				Logger:  logger.New("error"),
+				Config:  &config.Config{},
+				UseCase: &usecase.UseCase{PhotoRepo: photos},
+			}
+
+			menu := entity.Menu{
+				BusinessID: testBusinessID,
+				Sections: []entity.MenuSection{{
+					Name: "Mains",
+					Items: []entity.MenuItem{{
+						Name:     "Plov",
+						PhotoID:  tt.photoID,
+						PhotoURL: "https://attacker.example.com/spoofed.jpg",
+					}},
+				}},
+			}
+
+			ctx, w := newPrincipalContext(entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, http.MethodPost, "")
+
+			ok := h.prepareMenu(ctx, &menu)
+			if ok != tt.ok {
+				t.Fatalf("prepareMenu() = %v, want %v: %s", ok, tt.ok, w.Body.String())
+			}
+			if !ok {
+				if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "sections[0].items[0].photo_id") {
+					t.Fatalf("expected a validation error on the photo, got %d: %s", w.Code, w.Body.String())
+				}
+				return
+			}
+
+			section := menu.Sections[0]
+			item := section.Items[0]
+			if section.ID == "" || item.ID == "" {
+				t.Fatal("expected section and item IDs to be generated")
+			}
+			if item.DietaryTags == nil {
+				t.Fatal("expected dietary tags to be an empty list")
+			}
+			if item.PhotoURL != tt.url {
+				t.Fatalf("PhotoURL = %q, want %q", item.PhotoURL, tt.url)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/etc"
+)
+
+// contentOwner loads a reportable piece of content and returns the ID of the user who posted it.
+func (h *Handler) contentOwner(ctx *gin.Context, targetType, targetID string) (string, error) {
+	switch targetType {
+	case entity.ReportTargetBusiness:
+		business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: targetID})
+		return business.CreatedBy, err
+	case entity.ReportTargetReview:
+		review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: targetID})
+		return review.UserID, err
+	case entity.ReportTargetReviewReply:
+		reply, err := h.UseCase.ReviewReplyRepo.GetSingle(ctx, entity.ReviewReplySingleRequest{ID: targetID})
+		return reply.UserID, err
+	case entity.ReportTargetPhoto:
+		photo, err := h.UseCase.PhotoRepo.GetSingle(ctx, entity.Id{ID: targetID})
+		return photo.UserID, err
+	}
+
+	return "", fmt.Errorf("contentOwner - unknown target type %q", targetType)
+}
+
+// setContentStatus moves a piece of content to the status to, if it is in the status from. An empty from matches any status.
+// Review replies have no status and can only be deleted.
+func (h *Handler) setContentStatus(ctx *gin.Context, targetType, targetID, from, to string) error {
+	req := entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: targetID}},
+		Items:  []entity.UpdateFieldItem{{Column: "status", Value: to}},
+	}
+	if from != "" {
+		req.Filter = append(req.Filter, entity.Filter{Column: "status", Type: "eq", Value: from})
+	}
+
+	var err error
+
+	switch targetType {
+	case entity.ReportTargetBusiness:
+		_, err = h.UseCase.BusinessRepo.UpdateField(ctx, req)
+	case entity.ReportTargetReview:
+		_, err = h.UseCase.ReviewRepo.UpdateField(ctx, req)
+	case entity.ReportTargetPhoto:
+		_, err = h.UseCase.PhotoRepo.UpdateField(ctx, req)
+	default:
+		err = fmt.Errorf("setContentStatus - %q has no status", targetType)
+	}
+
+	return err
+}
+
+// notifyModerationWarning emails a user whose content was actioned with a warning.
+func (h *Handler) notifyModerationWarning(email string, warning etc.ModerationWarning) {
+	emailBody, err := etc.GenerateModerationWarningEmailBody(warning)
+	if err != nil {
+		h.Logger.Error(err, "Error generating moderation warning email body")
+		return
+	}
+
+	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, email, "Warning about your content on YALP", emailBody)
+	if err != nil {
+		h.Logger.Error(err, "Error sending moderation warning email")
+	}
+}
+
+// applyModerationAction prepares the change of the content of the case in step, or loads the author of the content
+// to warn or block and returns them. Blocking takes effect right away, before the case is resolved.
+// Like AuthorizeResource, it writes the error response and returns false when the action can't be applied.
+func (h *Handler) applyModerationAction(ctx *gin.Context, item entity.ModerationCase, body entity.ModerationActionRequest,
+	step *entity.ModerationStep) (entity.User, bool) {
+	switch body.Action {
+	case entity.ModerationActionHide:
+		if item.TargetType == entity.ReportTargetReviewReply {
+			h.ReturnError(ctx, config.ErrorBadRequest, "Review replies can't be hidden, delete them instead", http.StatusBadRequest)
+			return entity.User{}, false
+		}
+
+		step.ContentStatus = entity.ContentStatusHidden
+		return entity.User{}, true
+
+	case entity.ModerationActionDelete:
+		step.DeleteContent = true
+		return entity.User{}, true
+	}
+
+	if item.TargetOwnerID == "" {
+		h.ReturnError(ctx, config.ErrorNotFound, "The author of this content no longer exists", http.StatusNotFound)
+		return entity.User{}, false
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: item.TargetOwnerID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return user, false
+	}
+
+	if body.Action == entity.ModerationActionBlockUser {
+		if ok, message := h.canManageUser(ctx, user); !ok {
+			h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+			return user, false
+		}
+
+		err = h.blockUser(ctx, user, "Moderation: "+body.ReasonCode)
+		if h.HandleDbError(ctx, err, "Error blocking user") {
+			return user, false
+		}
+	}
+
+	return user, true
+}
+
+// applyModerationStep moves the case, its reports and its content in one transaction and records the step in the history.
+// Like AuthorizeResource, it writes the error response and returns false when the step can't be applied.
+func (h *Handler) applyModerationStep(ctx *gin.Context, step entity.ModerationStep, message string) bool {
+	step.History.CaseID = step.Case.ID
+	step.History.ActorID = GetPrincipal(ctx).UserID
+
+	err := h.UseCase.ModerationCaseRepo.Apply(ctx, step)
+	if err == entity.ErrModerationCaseResolved {
+		h.ReturnError(ctx, config.ErrorConflict, "Moderation case is already resolved", http.StatusConflict)
+		return false
+	}
+
+	return !h.HandleDbError(ctx, err, message)
+}
+
+// getUnresolvedModerationCase loads the case from the path and checks that it can still be worked on.
+func (h *Handler) getUnresolvedModerationCase(ctx *gin.Context) (entity.ModerationCase, bool) {
+	item, err := h.UseCase.ModerationCaseRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting moderation case") {
+		return item, false
+	}
+
+	if item.Status != entity.ModerationStatusOpen && item.Status != entity.ModerationStatusInReview {
+		h.ReturnError(ctx, config.ErrorConflict, "Moderation case is already resolved", http.StatusConflict)
+		return item, false
+	}
+
+	return item, true
+}
+
+// GetModerationCases godoc
+// @Router /admin/moderation/list [get]
+// @Summary Get the moderation queue
+// @Description Get reported content, most reported first
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param status query string false "open, in_review, actioned or dismissed"
+// @Param target_type query string false "business, review, review_reply or photo"
+// @Param assignee_id query string false "assignee_id"
+// @Success 200 {object} entity.ModerationCaseList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetModerationCases(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	for _, column := range []string{"status", "target_type", "assignee_id"} {
+		if value := ctx.Query(column); value != "" {
+			req.Filters = append(req.Filters, entity.Filter{
+				Column: column,
+				Type:   "eq",
+				Value:  value,
+			})
+		}
+	}
+
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{Column: "report_count", Order: "desc"},
+		entity.OrderBy{Column: "created_at", Order: "asc"},
+	)
+
+	cases, err := h.UseCase.ModerationCaseRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting moderation cases") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cases)
+}
+
+// GetModerationCase godoc
+// @Router /admin/moderation/{id} [get]
+// @Summary Get a moderation case
+// @Description Get a moderation case with its reports and history
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Case ID"
+// @Success 200 {object} entity.ModerationCase
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetModerationCase(ctx *gin.Context) {
+	item, err := h.UseCase.ModerationCaseRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting moderation case") {
+		return
+	}
+
+	reports, err := h.UseCase.ReportRepo.GetList(ctx, entity.GetListFilter{
+		Limit:   100,
+		Filters: []entity.Filter{{Column: "case_id", Type: "eq", Value: item.ID}},
+		OrderBy: []entity.OrderBy{{Column: "created_at", Order: "asc"}},
+	})
+	if h.HandleDbError(ctx, err, "Error getting reports") {
+		return
+	}
+
+	history, err := h.UseCase.ModerationHistoryRepo.GetList(ctx, entity.GetListFilter{
+		Limit:   100,
+		Filters: []entity.Filter{{Column: "case_id", Type: "eq", Value: item.ID}},
+		OrderBy: []entity.OrderBy{{Column: "created_at", Order: "asc"}},
+	})
+	if h.HandleDbError(ctx, err, "Error getting moderation history") {
+		return
+	}
+
+	item.Reports = reports.Items
+	item.History = history.Items
+
+	ctx.JSON(http.StatusOK, item)
+}
+
+// ClaimModerationCase godoc
+// @Router /admin/moderation/{id}/claim [post]
+// @Summary Claim a moderation case
+// @Description Assign an unresolved case to yourself and mark it in review
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Case ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) ClaimModerationCase(ctx *gin.Context) {
+	item, ok := h.getUnresolvedModerationCase(ctx)
+	if !ok {
+		return
+	}
+
+	if !h.applyModerationStep(ctx, entity.ModerationStep{
+		Case:    item,
+		Status:  entity.ModerationStatusInReview,
+		History: entity.ModerationHistory{Action: entity.ModerationActionClaim},
+	}, "Error claiming moderation case") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Moderation case claimed successfully",
+	})
+}
+
+// ActionModerationCase godoc
+// @Router /admin/moderation/{id}/action [post]
+// @Summary Take action on a moderation case
+// @Description Hide or delete the reported content, or warn or block its author, and resolve the case
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Case ID"
+// @Param body body entity.ModerationActionRequest true "Action"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) ActionModerationCase(ctx *gin.Context) {
+	var body entity.ModerationActionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	item, ok := h.getUnresolvedModerationCase(ctx)
+	if !ok {
+		return
+	}
+
+	step := entity.ModerationStep{
+		Case:   item,
+		Status: entity.ModerationStatusActioned,
+		History: entity.ModerationHistory{
+			Action:     body.Action,
+			ReasonCode: body.ReasonCode,
+			Note:       body.Note,
+		},
+	}
+
+	author, ok := h.applyModerationAction(ctx, item, body, &step)
+	if !ok {
+		return
+	}
+
+	if !h.applyModerationStep(ctx, step, "Error resolving moderation case") {
+		return
+	}
+
+	if body.Action == entity.ModerationActionWarnUser {
+		go h.notifyModerationWarning(author.Email, etc.ModerationWarning{
+			ContentType: item.TargetType,
+			Reason:      body.ReasonCode,
+			Note:        body.Note,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Moderation action applied successfully",
+	})
+}
+
+// DismissModerationCase godoc
+// @Router /admin/moderation/{id}/dismiss [post]
+// @Summary Dismiss a moderation case
+// @Description Resolve a case without taking action on the content. Content held by the content filter is published.
+// @Security BearerAuth
+// @Tags moderation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Case ID"
+// @Param body body entity.ModerationDismissRequest true "Reason"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) DismissModerationCase(ctx *gin.Context) {
+	var body entity.ModerationDismissRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	item, ok := h.getUnresolvedModerationCase(ctx)
+	if !ok {
+		return
+	}
+
+	step := entity.ModerationStep{
+		Case:   item,
+		Status: entity.ModerationStatusDismissed,
+		History: entity.ModerationHistory{
+			Action:     entity.ModerationActionDismiss,
+			ReasonCode: body.ReasonCode,
+			Note:       body.Note,
+		},
+	}
+
+	// review replies have no status
+	if item.TargetType != entity.ReportTargetReviewReply {
+		step.ContentFrom = entity.ContentStatusPending
+		step.ContentStatus = entity.ContentStatusPublished
+	}
+
+	if !h.applyModerationStep(ctx, step, "Error dismissing moderation case") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Moderation case dismissed successfully",
+	})
+}
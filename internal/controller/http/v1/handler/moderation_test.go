@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+const testCaseID = "66666666-6666-6666-6666-666666666666"
+
+// fakeModerationCaseRepo serves one case and keeps the steps applied to it.
+type fakeModerationCaseRepo struct {
+	usecase.ModerationCaseRepoI
+	item  entity.ModerationCase
+	err   error
+	steps []entity.ModerationStep
+}
+
+func (r *fakeModerationCaseRepo) GetSingle(ctx context.Context, req entity.Id) (entity.ModerationCase, error) {
+	if req.ID != r.item.ID {
+		return entity.ModerationCase{}, pgx.ErrNoRows
+	}
+	return r.item, nil
+}
+
+func (r *fakeModerationCaseRepo) Apply(ctx context.Context, req entity.ModerationStep) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.steps = append(r.steps, req)
+	return nil
+}
+
+func TestModerationCase_AppliesContentChangeWithTheCase(t *testing.T) {
+	admin := entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin, SessionID: testSessionID}
+
+	tests := []struct {
+		name       string
+		targetType string
+		action     string // empty dismisses the case
+		err        error
+		status     int
+		want       entity.ModerationStep
+	}{
+		{name: "hide a review", targetType: entity.ReportTargetReview, action: entity.ModerationActionHide, status: http.StatusOK,
+			want: entity.ModerationStep{Status: entity.ModerationStatusActioned, ContentStatus: entity.ContentStatusHidden}},
+		{name: "hide a reply", targetType: entity.ReportTargetReviewReply, action: entity.ModerationActionHide, status: http.StatusBadRequest},
+		{name: "delete a reply", targetType: entity.ReportTargetReviewReply, action: entity.ModerationActionDelete, status: http.StatusOK,
+			want: entity.ModerationStep{Status: entity.ModerationStatusActioned, DeleteContent: true}},
+		{name: "dismiss a photo", targetType: entity.ReportTargetPhoto, status: http.StatusOK,
+			want: entity.ModerationStep{Status: entity.ModerationStatusDismissed,
+				ContentFrom: entity.ContentStatusPending, ContentStatus: entity.ContentStatusPublished}},
+		{name: "dismiss a reply", targetType: entity.ReportTargetReviewReply, status: http.StatusOK,
+			want: entity.ModerationStep{Status: entity.ModerationStatusDismissed}},
+		{name: "resolved in the meantime", targetType: entity.ReportTargetBusiness, action: entity.ModerationActionDelete,
+			err: entity.ErrModerationCaseResolved, status: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeModerationCaseRepo{
+				item: entity.ModerationCase{ID: testCaseID, TargetType: tt.targetType, TargetID: testTargetID,
+					Status: entity.ModerationStatusOpen},
+				err: tt.err,
+			}
+			h := &Handler{
+				Logger:  logger.New("error"),
+				Config:  &config.Config{},
+				UseCase: &usecase.UseCase{ModerationCaseRepo: repo},
+			}
+
+			body := `{"reason_code": "spam"}`
+			if tt.action != "" {
+				body = `{"action": "` + tt.action + `", "reason_code": "spam"}`
+			}
+
+			ctx, w := newPrincipalContext(admin, http.MethodPost, body)
+			ctx.Params = gin.Params{{Key: "id", Value: testCaseID}}
+
+			if tt.action == "" {
+				h.DismissModerationCase(ctx)
+			} else {
+				h.ActionModerationCase(ctx)
+			}
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				if len(repo.steps) != 0 {
+					t.Fatalf("expected no step to be applied, got %+v", repo.steps)
+				}
+				return
+			}
+
+			if len(repo.steps) != 1 {
+				t.Fatalf("expected one step, got %d", len(repo.steps))
+			}
+			step := repo.steps[0]
+			if step.Case.ID != testCaseID || step.History.CaseID != testCaseID || step.History.ActorID != testAdminID {
+				t.Fatalf("step not tied to the case and its moderator: %+v", step)
+			}
+			if step.Status != tt.want.Status || step.ContentFrom != tt.want.ContentFrom ||
+				step.ContentStatus != tt.want.ContentStatus || step.DeleteContent != tt.want.DeleteContent {
+				t.Fatalf("got step %+v, want %+v", step, tt.want)
+			}
+		})
+	}
+}
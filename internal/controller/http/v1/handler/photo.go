@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// CreatePhoto godoc
+// @Router /photo [post]
+// @Summary Add a photo
+// @Description Add a photo of a business
+// @Security BearerAuth
+// @Tags photo
+// @Accept  json
+// @Produce  json
+// @Param photo body entity.CreatePhotoRequest true "Photo object"
+// @Success 201 {object} entity.Photo
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreatePhoto(ctx *gin.Context) {
+	var body entity.CreatePhotoRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	if business.Status != entity.ContentStatusPublished {
+		h.ReturnError(ctx, config.ErrorNotFound, "Business not found", http.StatusNotFound)
+		return
+	}
+
+	photo, err := h.UseCase.PhotoRepo.Create(ctx, entity.Photo{
+		BusinessID: body.BusinessID,
+		UserID:     GetPrincipal(ctx).UserID,
+		URL:        body.URL,
+		Caption:    body.Caption,
+		Status:     entity.ContentStatusPublished,
+	})
+	if h.HandleDbError(ctx, err, "Error creating photo") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, photo)
+}
+
+// GetPhotos godoc
+// @Router /photo/list [get]
+// @Summary Get a list of photos
+// @Description Get published photos of a business or a user, newest first
+// @Security BearerAuth
+// @Tags photo
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param business_id query string false "business_id"
+// @Param user_id query string false "user_id"
+// @Success 200 {object} entity.PhotoList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPhotos(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "status",
+		Type:   "eq",
+		Value:  entity.ContentStatusPublished,
+	})
+
+	for _, column := range []string{"business_id", "user_id"} {
+		if value := ctx.Query(column); value != "" {
+			req.Filters = append(req.Filters, entity.Filter{
+				Column: column,
+				Type:   "eq",
+				Value:  value,
+			})
+		}
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	photos, err := h.UseCase.PhotoRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting photos") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, photos)
+}
+
+// DeletePhoto godoc
+// @Router /photo/{id} [delete]
+// @Summary Delete a photo
+// @Description Delete a photo you added
+// @Security BearerAuth
+// @Tags photo
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Photo ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeletePhoto(ctx *gin.Context) {
+	var req entity.Id
+
+	req.ID = ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourcePhoto, req.ID) {
+		return
+	}
+
+	err := h.UseCase.PhotoRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting photo") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Photo deleted successfully",
+	})
+}
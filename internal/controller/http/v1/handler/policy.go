@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// canChangePolicy reports whether the current principal may change the access policy.
+// Admins can read it, but only a superadmin can change it, otherwise an admin could grant themselves anything.
+func (h *Handler) canChangePolicy(ctx *gin.Context) bool {
+	return GetPrincipal(ctx).UserRole == entity.UserRoleSuperAdmin
+}
+
+// reloadPolicy applies a policy change on this instance right away.
+// Other instances pick it up through the casbin_rule trigger and the policy watcher.
+func (h *Handler) reloadPolicy() {
+	err := h.Enforcer.LoadPolicy()
+	if err != nil {
+		h.Logger.Error(err, "Error reloading policy")
+	}
+}
+
+// GetPolicies godoc
+// @Router /admin/policy/list [get]
+// @Summary Get the access policy
+// @Description Get every policy and role inheritance rule currently enforced
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} entity.PolicyList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPolicies(ctx *gin.Context) {
+	response := entity.PolicyList{
+		Policies: []entity.Policy{},
+		Roles:    []entity.RoleInheritance{},
+	}
+
+	for _, rule := range h.Enforcer.GetPolicy() {
+		if len(rule) < 3 {
+			continue
+		}
+		response.Policies = append(response.Policies, entity.Policy{Subject: rule[0], Object: rule[1], Action: rule[2]})
+	}
+
+	for _, rule := range h.Enforcer.GetGroupingPolicy() {
+		if len(rule) < 2 {
+			continue
+		}
+		response.Roles = append(response.Roles, entity.RoleInheritance{Role: rule[0], Parent: rule[1]})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreatePolicy godoc
+// @Router /admin/policy [post]
+// @Summary Add a policy
+// @Description Allow a role to perform actions on a path
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param body body entity.Policy true "Policy"
+// @Success 201 {object} entity.Policy
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreatePolicy(ctx *gin.Context) {
+	var body entity.Policy
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.canChangePolicy(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only a superadmin can change the access policy", http.StatusForbidden)
+		return
+	}
+
+	err := h.UseCase.PolicyRepo.Create(ctx, entity.PolicyRule{
+		PType: entity.PolicyTypePolicy,
+		Rule:  []string{body.Subject, body.Object, body.Action},
+	})
+	if h.HandleDbError(ctx, err, "Error creating policy") {
+		return
+	}
+
+	h.reloadPolicy()
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionPolicyAdd,
+		TargetType: entity.AuditTargetPolicy,
+		TargetID:   body.Subject,
+	}, nil, body)
+
+	ctx.JSON(http.StatusCreated, body)
+}
+
+// DeletePolicy godoc
+// @Router /admin/policy [delete]
+// @Summary Remove a policy
+// @Description Remove a policy
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param body body entity.Policy true "Policy"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeletePolicy(ctx *gin.Context) {
+	var body entity.Policy
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.canChangePolicy(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only a superadmin can change the access policy", http.StatusForbidden)
+		return
+	}
+
+	rows, err := h.UseCase.PolicyRepo.Delete(ctx, entity.PolicyRule{
+		PType: entity.PolicyTypePolicy,
+		Rule:  []string{body.Subject, body.Object, body.Action},
+	})
+	if h.HandleDbError(ctx, err, "Error deleting policy") {
+		return
+	}
+
+	if rows.RowsEffected == 0 {
+		h.ReturnError(ctx, config.ErrorNotFound, "Policy not found", http.StatusNotFound)
+		return
+	}
+
+	h.reloadPolicy()
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionPolicyRemove,
+		TargetType: entity.AuditTargetPolicy,
+		TargetID:   body.Subject,
+	}, body, nil)
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Policy deleted successfully",
+	})
+}
+
+// CreateRoleInheritance godoc
+// @Router /admin/policy/role [post]
+// @Summary Add a role inheritance rule
+// @Description Make a role inherit every policy of a parent role
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param body body entity.RoleInheritance true "Role inheritance"
+// @Success 201 {object} entity.RoleInheritance
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateRoleInheritance(ctx *gin.Context) {
+	var body entity.RoleInheritance
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.canChangePolicy(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only a superadmin can change the access policy", http.StatusForbidden)
+		return
+	}
+
+	err := h.UseCase.PolicyRepo.Create(ctx, entity.PolicyRule{
+		PType: entity.PolicyTypeRole,
+		Rule:  []string{body.Role, body.Parent},
+	})
+	if h.HandleDbError(ctx, err, "Error creating role inheritance") {
+		return
+	}
+
+	h.reloadPolicy()
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionRoleAdd,
+		TargetType: entity.AuditTargetPolicy,
+		TargetID:   body.Role,
+	}, nil, body)
+
+	ctx.JSON(http.StatusCreated, body)
+}
+
+// DeleteRoleInheritance godoc
+// @Router /admin/policy/role [delete]
+// @Summary Remove a role inheritance rule
+// @Description Remove a role inheritance rule
+// @Security BearerAuth
+// @Tags admin
+// @Accept  json
+// @Produce  json
+// @Param body body entity.RoleInheritance true "Role inheritance"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteRoleInheritance(ctx *gin.Context) {
+	var body entity.RoleInheritance
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.canChangePolicy(ctx) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only a superadmin can change the access policy", http.StatusForbidden)
+		return
+	}
+
+	rows, err := h.UseCase.PolicyRepo.Delete(ctx, entity.PolicyRule{
+		PType: entity.PolicyTypeRole,
+		Rule:  []string{body.Role, body.Parent},
+	})
+	if h.HandleDbError(ctx, err, "Error deleting role inheritance") {
+		return
+	}
+
+	if rows.RowsEffected == 0 {
+		h.ReturnError(ctx, config.ErrorNotFound, "Role inheritance not found", http.StatusNotFound)
+		return
+	}
+
+	h.reloadPolicy()
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionRoleRemove,
+		TargetType: entity.AuditTargetPolicy,
+		TargetID:   body.Role,
+	}, body, nil)
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Role inheritance deleted successfully",
+	})
+}
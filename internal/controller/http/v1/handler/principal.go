@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/internal/entity"
+)
+
+const principalKey = "principal"
+
+type principalContextKey struct{}
+
+// SetPrincipal stores the authenticated caller in both the gin and the request context.
+func SetPrincipal(c *gin.Context, principal entity.Principal) {
+	c.Set(principalKey, principal)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), principalContextKey{}, principal))
+}
+
+// GetPrincipal returns the authenticated caller, or an empty Principal for anonymous requests.
+func GetPrincipal(c *gin.Context) entity.Principal {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return entity.Principal{}
+	}
+
+	principal, _ := value.(entity.Principal)
+
+	return principal
+}
+
+// PrincipalFromContext returns the authenticated caller stored in a request context.
+func PrincipalFromContext(ctx context.Context) (entity.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(entity.Principal)
+	return principal, ok
+}
+
+// principalFromClaims builds a Principal from verified JWT claims. Claims of an unexpected type are ignored.
+func principalFromClaims(claims map[string]interface{}) entity.Principal {
+	str := func(key string) string {
+		value, _ := claims[key].(string)
+		return value
+	}
+
+	return entity.Principal{
+		UserID:    str("sub"),
+		UserRole:  str("user_role"),
+		UserType:  str("user_type"),
+		Platform:  str("platform"),
+		SessionID: str("session_id"),
+	}
+}
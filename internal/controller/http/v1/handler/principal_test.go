@@ -0,0 +1,344 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/casbin/casbin"
+	"github.com/gin-gonic/gin"
+	rediscache "github.com/golanguzb70/redis-cache"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/jwt"
+	"yalp_ulab/pkg/logger"
+)
+
+const (
+	testSecret    = "test-secret"
+	testUserID    = "11111111-1111-1111-1111-111111111111"
+	testSessionID = "22222222-2222-2222-2222-222222222222"
+	spoofedUserID = "33333333-3333-3333-3333-333333333333"
+)
+
+const testModel = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch(r.obj, p.obj) && regexMatch(r.act, p.act) || r.sub == "superadmin"
+`
+
+// fakeRedis is an in-memory rediscache.RedisCache.
+type fakeRedis struct {
+	rediscache.RedisCache
+	items map[string]string
+}
+
+func (r *fakeRedis) Set(ctx context.Context, key string, value string, expiration int) error {
+	r.items[key] = value
+	return nil
+}
+
+func (r *fakeRedis) Get(ctx context.Context, key string) (string, error) {
+	value, ok := r.items[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return value, nil
+}
+
+func (r *fakeRedis) Del(ctx context.Context, key string) error {
+	delete(r.items, key)
+	return nil
+}
+
+type fakeUserRepo struct {
+	usecase.UserRepoI
+	user entity.User
+}
+
+func (r *fakeUserRepo) GetSingle(ctx context.Context, req entity.UserSingleRequest) (entity.User, error) {
+	if req.ID != r.user.ID && (req.Email == "" || req.Email != r.user.Email) {
+		return entity.User{}, errors.New("no rows in result set")
+	}
+	return r.user, nil
+}
+
+type fakeSessionRepo struct {
+	usecase.SessionRepoI
+	session entity.Session
+	updates []entity.UpdateFieldRequest
+}
+
+func (r *fakeSessionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Session, error) {
+	if req.ID != r.session.ID {
+		return entity.Session{}, errors.New("no rows in result set")
+	}
+	return r.session, nil
+}
+
+func (r *fakeSessionRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	r.updates = append(r.updates, req)
+	return entity.RowsEffected{RowsEffected: 1}, nil
+}
+
+// newTestRouter serves /v1/auth/whoami to everyone and /v1/admin/check to admins only.
+// Both endpoints respond with the principal AuthMiddleware resolved.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	enforcer := casbin.NewSyncedEnforcer(casbin.NewModel(testModel), false)
+	enforcer.AddPolicy("unauthorized", "/v1/auth/*", "GET")
+	enforcer.AddPolicy("admin", "/v1/admin/*", "GET")
+	enforcer.AddGroupingPolicy("user", "unauthorized")
+	enforcer.AddGroupingPolicy("admin", "user")
+
+	now := time.Now()
+
+	useCase := &usecase.UseCase{
+		UserRepo: &fakeUserRepo{user: entity.User{
+			ID:       testUserID,
+			UserRole: "user",
+			Status:   entity.UserStatusActive,
+		}},
+		SessionRepo: &fakeSessionRepo{session: entity.Session{
+			ID:           testSessionID,
+			UserID:       testUserID,
+			IsActive:     true,
+			ExpiresAt:    now.Add(time.Hour).Format(time.RFC3339),
+			LastActiveAt: now.Format(time.RFC3339),
+		}},
+	}
+
+	h := NewHandler(
+		logger.New("error"),
+		&config.Config{JWT: config.JWT{Secret: testSecret}},
+		useCase,
+		&fakeRedis{items: map[string]string{}},
+		nil,
+		enforcer,
+	)
+
+	whoami := func(c *gin.Context) {
+		c.JSON(http.StatusOK, GetPrincipal(c))
+	}
+
+	router := gin.New()
+	router.Use(h.AuthMiddleware())
+	router.GET("/v1/auth/whoami", whoami)
+	router.GET("/v1/admin/check", whoami)
+
+	return router
+}
+
+func spoofIdentityHeaders(req *http.Request) {
+	req.Header.Set("sub", spoofedUserID)
+	req.Header.Set("user_role", "admin")
+	req.Header.Set("user_type", "admin")
+	req.Header.Set("session_id", testSessionID)
+	req.Header.Set("platform", "web")
+}
+
+func serve(t *testing.T, router *gin.Engine, req *http.Request) (*httptest.ResponseRecorder, entity.Principal) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var principal entity.Principal
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &principal); err != nil {
+			t.Fatalf("decoding principal: %v", err)
+		}
+	}
+
+	return w, principal
+}
+
+func TestAuthMiddleware_IgnoresSpoofedHeadersWithoutToken(t *testing.T) {
+	router := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/whoami", nil)
+	spoofIdentityHeaders(req)
+
+	w, principal := serve(t, router, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if principal != (entity.Principal{}) {
+		t.Fatalf("expected an anonymous principal, got %+v", principal)
+	}
+}
+
+func TestAuthMiddleware_SpoofedRoleHeaderDoesNotGrantAccess(t *testing.T) {
+	router := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/check", nil)
+	spoofIdentityHeaders(req)
+
+	w, _ := serve(t, router, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_IgnoresSpoofedHeadersWithInvalidToken(t *testing.T) {
+	router := newTestRouter(t)
+
+	token, err := jwt.GenerateJWT(map[string]interface{}{
+		"sub":        testUserID,
+		"user_role":  "admin",
+		"session_id": testSessionID,
+	}, "wrong-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	spoofIdentityHeaders(req)
+
+	w, principal := serve(t, router, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if principal != (entity.Principal{}) {
+		t.Fatalf("expected an anonymous principal, got %+v", principal)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/admin/check", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	spoofIdentityHeaders(req)
+
+	w, _ = serve(t, router, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_PrincipalComesFromToken(t *testing.T) {
+	router := newTestRouter(t)
+
+	token, err := jwt.GenerateJWT(map[string]interface{}{
+		"sub":        testUserID,
+		"user_role":  "user",
+		"user_type":  "user",
+		"platform":   "mobile",
+		"session_id": testSessionID,
+	}, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	spoofIdentityHeaders(req)
+
+	w, principal := serve(t, router, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	expected := entity.Principal{
+		UserID:    testUserID,
+		UserRole:  "user",
+		UserType:  "user",
+		Platform:  "mobile",
+		SessionID: testSessionID,
+	}
+	if principal != expected {
+		t.Fatalf("expected %+v, got %+v", expected, principal)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/admin/check", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	spoofIdentityHeaders(req)
+
+	w, _ = serve(t, router, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected a spoofed role header to be ignored, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_RejectsTokenForUnknownSession(t *testing.T) {
+	router := newTestRouter(t)
+
+	token, err := jwt.GenerateJWT(map[string]interface{}{
+		"sub":        spoofedUserID,
+		"user_role":  "admin",
+		"session_id": testSessionID,
+	}, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/whoami", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w, _ := serve(t, router, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
+// TestRBACMatcher runs the matcher of config/rbac.conf against the default policies: roles inherit from the roles
+// below them and a superadmin is allowed everything, with or without a policy.
+func TestRBACMatcher(t *testing.T) {
+	model, err := os.ReadFile("../../../../../config/rbac.conf")
+	if err != nil {
+		t.Fatalf("reading rbac.conf: %v", err)
+	}
+
+	enforcer := casbin.NewSyncedEnforcer(casbin.NewModel(string(model)), false)
+	enforcer.AddPolicy("unauthorized", "/v1/auth/*", "GET|POST")
+	enforcer.AddPolicy("user", "/v1/user/:id", "GET")
+	enforcer.AddPolicy("admin", "/v1/admin/*", "GET|POST|PUT|DELETE")
+	enforcer.AddGroupingPolicy("user", "unauthorized")
+	enforcer.AddGroupingPolicy("admin", "user")
+
+	tests := []struct {
+		sub, obj, act string
+		allowed       bool
+	}{
+		{"unauthorized", "/v1/auth/login", "POST", true},
+		{"unauthorized", "/v1/user/:id", "GET", false},
+		{"user", "/v1/auth/login", "POST", true},
+		{"user", "/v1/user/:id", "GET", true},
+		{"user", "/v1/admin/moderation/list", "GET", false},
+		{"admin", "/v1/admin/moderation/list", "GET", true},
+		{"admin", "/v1/admin/moderation/:id/action", "POST", true},
+		{"admin", "/v1/policy/list", "GET", false},
+		{"superadmin", "/v1/admin/moderation/list", "GET", true},
+		{"superadmin", "/v1/policy/list", "DELETE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sub+" "+tt.act+" "+tt.obj, func(t *testing.T) {
+			allowed, err := enforcer.EnforceSafe(tt.sub, tt.obj, tt.act)
+			if err != nil {
+				t.Fatalf("EnforceSafe() error: %v", err)
+			}
+			if allowed != tt.allowed {
+				t.Fatalf("EnforceSafe() = %v, want %v", allowed, tt.allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// getVisibleProfile loads the profile of a user. Profiles of blocked or deleted users are only visible to admins.
+func (h *Handler) getVisibleProfile(ctx *gin.Context, userID string) (entity.ProfileRecord, bool) {
+	record, err := h.UseCase.ProfileRepo.GetSingle(ctx, entity.Id{ID: userID})
+	if h.HandleDbError(ctx, err, "Error getting profile") {
+		return record, false
+	}
+
+	if record.Status != entity.UserStatusActive && !GetPrincipal(ctx).IsAdmin() {
+		h.ReturnError(ctx, config.ErrorNotFound, "User not found", http.StatusNotFound)
+		return record, false
+	}
+
+	return record, true
+}
+
+// profilePartVisible reports whether the current principal can see a part of a profile with the given visibility.
+// The user themself and admins see everything.
+func (h *Handler) profilePartVisible(ctx *gin.Context, userID, visibility string) (bool, error) {
+	principal := GetPrincipal(ctx)
+	if principal.UserID == userID || principal.IsAdmin() {
+		return true, nil
+	}
+
+	switch visibility {
+	case entity.ProfileVisibilityPublic:
+		return true, nil
+	case entity.ProfileVisibilityFollowers:
+		if !principal.IsAuthenticated() {
+			return false, nil
+		}
+		return h.UseCase.FollowRepo.Exists(ctx, entity.Follow{FollowerID: principal.UserID, FolloweeID: userID})
+	}
+
+	return false, nil
+}
+
+// toProfile leaves out the parts of the profile the current principal is not allowed to see.
+func (h *Handler) toProfile(ctx *gin.Context, record entity.ProfileRecord) (entity.Profile, error) {
+	profile := entity.Profile{
+		ID:          record.ID,
+		DisplayName: entity.PublicName(record.DisplayName, record.FullName),
+		AvatarURL:   record.AvatarURL,
+		CreatedAt:   record.CreatedAt,
+	}
+
+	visible, err := h.profilePartVisible(ctx, record.ID, record.Privacy.About)
+	if err != nil {
+		return profile, err
+	}
+	if visible {
+		profile.Bio = record.Bio
+		profile.City = record.City
+	}
+
+	visible, err = h.profilePartVisible(ctx, record.ID, record.Privacy.Stats)
+	if err != nil {
+		return profile, err
+	}
+	if visible {
+		stats := record.Stats
+		profile.Stats = &stats
+	}
+
+	if GetPrincipal(ctx).UserID == record.ID {
+		privacy := record.Privacy
+		profile.Privacy = &privacy
+	}
+
+	return profile, nil
+}
+
+// GetProfile godoc
+// @Router /profile/{id} [get]
+// @Summary Get the public profile of a user
+// @Description Get the profile of a user. Bio, city and stats are shown as the user's privacy settings allow.
+// @Security BearerAuth
+// @Tags profile
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} entity.Profile
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetProfile(ctx *gin.Context) {
+	record, ok := h.getVisibleProfile(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	profile, err := h.toProfile(ctx, record)
+	if h.HandleDbError(ctx, err, "Error checking profile privacy") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfile godoc
+// @Router /profile [put]
+// @Summary Update your profile
+// @Description Update your display name, avatar, bio and city, and who can see them
+// @Security BearerAuth
+// @Tags profile
+// @Accept  json
+// @Produce  json
+// @Param profile body entity.UpdateProfileRequest true "Profile object"
+// @Success 200 {object} entity.Profile
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateProfile(ctx *gin.Context) {
+	var body entity.UpdateProfileRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	record, err := h.UseCase.ProfileRepo.GetSingle(ctx, entity.Id{ID: GetPrincipal(ctx).UserID})
+	if h.HandleDbError(ctx, err, "Error getting profile") {
+		return
+	}
+
+	record.DisplayName = body.DisplayName
+	record.AvatarURL = body.AvatarURL
+	record.Bio = body.Bio
+	record.City = body.City
+
+	if body.Privacy != nil {
+		// Parts left empty keep their current setting
+		if body.Privacy.About != "" {
+			record.Privacy.About = body.Privacy.About
+		}
+		if body.Privacy.Stats != "" {
+			record.Privacy.Stats = body.Privacy.Stats
+		}
+		if body.Privacy.CheckIns != "" {
+			record.Privacy.CheckIns = body.Privacy.CheckIns
+		}
+	}
+
+	err = h.UseCase.ProfileRepo.Update(ctx, record)
+	if h.HandleDbError(ctx, err, "Error updating profile") {
+		return
+	}
+
+	profile, err := h.toProfile(ctx, record)
+	if h.HandleDbError(ctx, err, "Error checking profile privacy") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
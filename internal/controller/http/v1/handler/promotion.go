@@ -0,0 +1,432 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// preparePromotion checks the period of a promotion, which must not have ended yet, and sets it in UTC.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) preparePromotion(ctx *gin.Context, promotion *entity.Promotion, startsAt, endsAt string) bool {
+	start, end, field, message := entity.PromotionPeriod(startsAt, endsAt, config.MaxPromotionDays)
+	if field == "" && !end.After(time.Now()) {
+		field, message = "ends_at", "must be in the future"
+	}
+
+	if field != "" {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   field,
+			Code:    config.ErrorInvalidValue,
+			Message: message,
+		})
+		return false
+	}
+
+	promotion.StartsAt = start.Format(time.RFC3339)
+	promotion.EndsAt = end.Format(time.RFC3339)
+
+	weekdays := []int{}
+	seen := map[int]bool{}
+	for _, day := range promotion.Weekdays {
+		if !seen[day] {
+			seen[day] = true
+			weekdays = append(weekdays, day)
+		}
+	}
+	sort.Ints(weekdays)
+	promotion.Weekdays = weekdays
+
+	return true
+}
+
+// locationFilters narrows a list down to businesses around the lat and lng query parameters, if they are set.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) locationFilters(ctx *gin.Context) ([]entity.Filter, bool) {
+	if ctx.Query("lat") == "" && ctx.Query("lng") == "" {
+		return nil, true
+	}
+
+	var (
+		center entity.Location
+		fields []entity.FieldError
+		err    error
+	)
+
+	center.Latitude, err = strconv.ParseFloat(ctx.Query("lat"), 64)
+	if err != nil || center.Latitude < -90 || center.Latitude > 90 {
+		fields = append(fields, entity.FieldError{
+			Field:   "lat",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be a latitude from -90 to 90",
+		})
+	}
+
+	center.Longitude, err = strconv.ParseFloat(ctx.Query("lng"), 64)
+	if err != nil || center.Longitude < -180 || center.Longitude > 180 {
+		fields = append(fields, entity.FieldError{
+			Field:   "lng",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be a longitude from -180 to 180",
+		})
+	}
+
+	radius, err := strconv.ParseFloat(ctx.DefaultQuery("radius", "5000"), 64)
+	if err != nil || radius <= 0 || radius > float64(config.MaxPromotionRadius) {
+		fields = append(fields, entity.FieldError{
+			Field:   "radius",
+			Code:    config.ErrorInvalidValue,
+			Message: fmt.Sprintf("must be a distance in meters up to %d", config.MaxPromotionRadius),
+		})
+	}
+
+	if len(fields) != 0 {
+		h.ReturnValidationError(ctx, fields...)
+		return nil, false
+	}
+
+	southWest, northEast := center.BoundingBox(radius)
+
+	return []entity.Filter{
+		{Column: "(b.location->>'latitude')::float8", Type: "gte", Value: strconv.FormatFloat(southWest.Latitude, 'f', -1, 64)},
+		{Column: "(b.location->>'latitude')::float8", Type: "lte", Value: strconv.FormatFloat(northEast.Latitude, 'f', -1, 64)},
+		{Column: "(b.location->>'longitude')::float8", Type: "gte", Value: strconv.FormatFloat(southWest.Longitude, 'f', -1, 64)},
+		{Column: "(b.location->>'longitude')::float8", Type: "lte", Value: strconv.FormatFloat(northEast.Longitude, 'f', -1, 64)},
+	}, true
+}
+
+// CreatePromotion godoc
+// @Router /business/{id}/promotions [post]
+// @Summary Add a deal or event to your business
+// @Description Add a deal or event that runs from starts_at to ends_at. Weekdays limit a deal to some days of the week, e.g. [2] for Tuesdays.
+// @Security BearerAuth
+// @Tags promotion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param promotion body entity.CreatePromotionRequest true "Promotion object"
+// @Success 201 {object} entity.Promotion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) CreatePromotion(ctx *gin.Context) {
+	var body entity.CreatePromotionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: businessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	promotion := entity.Promotion{
+		BusinessID:   business.ID,
+		BusinessName: business.Name,
+		Kind:         body.Kind,
+		Title:        body.Title,
+		Description:  body.Description,
+		Weekdays:     body.Weekdays,
+	}
+
+	if !h.preparePromotion(ctx, &promotion, body.StartsAt, body.EndsAt) {
+		return
+	}
+
+	promotion, err = h.UseCase.PromotionRepo.Create(ctx, promotion)
+	if h.HandleDbError(ctx, err, "Error creating promotion") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, promotion)
+}
+
+// GetBusinessPromotions godoc
+// @Router /business/{id}/promotions [get]
+// @Summary Get the promotions of a business
+// @Description Get the deals and events of a business that haven't ended, soonest first. Its owner can include the ones that have ended with expired=true.
+// @Security BearerAuth
+// @Tags promotion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param expired query bool false "expired"
+// @Success 200 {object} entity.PromotionList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessPromotions(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	business, ok := h.getVisibleBusiness(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "p.business_id",
+		Type:   "eq",
+		Value:  business.ID,
+	})
+
+	principal := GetPrincipal(ctx)
+	if ctx.Query("expired") != "true" || (business.CreatedBy != principal.UserID && !principal.IsAdmin()) {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "p.ends_at",
+			Type:   "gt",
+			Value:  time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "p.starts_at",
+		Order:  "asc",
+	})
+
+	promotions, err := h.UseCase.PromotionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting promotions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotions)
+}
+
+// GetPromotion godoc
+// @Router /business/promotions/{id} [get]
+// @Summary Get a promotion by ID
+// @Description Get a deal or event. Once it has ended only the owner of the business can see it.
+// @Security BearerAuth
+// @Tags promotion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} entity.Promotion
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetPromotion(ctx *gin.Context) {
+	promotion, err := h.UseCase.PromotionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting promotion") {
+		return
+	}
+
+	business, ok := h.getVisibleBusiness(ctx, promotion.BusinessID)
+	if !ok {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+	endsAt, _ := time.Parse(time.RFC3339, promotion.EndsAt)
+	if !endsAt.After(time.Now()) && business.CreatedBy != principal.UserID && !principal.IsAdmin() {
+		h.ReturnError(ctx, config.ErrorNotFound, "Promotion not found", http.StatusNotFound)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotion)
+}
+
+// GetPromotions godoc
+// @Router /promotion/list [get]
+// @Summary Find deals and events
+// @Description Find deals and events that haven't ended, soonest first. Narrow them down to a time range with from and to (RFC 3339), and to businesses around lat and lng with radius in meters (5000 by default).
+// @Security BearerAuth
+// @Tags promotion
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param kind query string false "kind" Enums(deal, event)
+// @Param from query string false "from"
+// @Param to query string false "to"
+// @Param lat query number false "lat"
+// @Param lng query number false "lng"
+// @Param radius query number false "radius"
+// @Success 200 {object} entity.PromotionList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetPromotions(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	kind := ctx.Query("kind")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+
+	from := time.Now()
+	if value := ctx.Query("from"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "from",
+				Code:    config.ErrorInvalidValue,
+				Message: "must be an RFC 3339 time, e.g. 2024-05-01T00:00:00+05:00",
+			})
+			return
+		}
+
+		// promotions that already ended stay out however far back the range goes
+		if t.After(from) {
+			from = t
+		}
+	}
+
+	req.Filters = append(req.Filters,
+		entity.Filter{
+			Column: "b.status",
+			Type:   "eq",
+			Value:  entity.ContentStatusPublished,
+		},
+		entity.Filter{
+			Column: "p.ends_at",
+			Type:   "gt",
+			Value:  from.UTC().Format(time.RFC3339),
+		},
+	)
+
+	if value := ctx.Query("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "to",
+				Code:    config.ErrorInvalidValue,
+				Message: "must be an RFC 3339 time, e.g. 2024-05-08T00:00:00+05:00",
+			})
+			return
+		}
+
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "p.starts_at",
+			Type:   "lt",
+			Value:  to.UTC().Format(time.RFC3339),
+		})
+	}
+
+	if kind != "" {
+		if kind != entity.PromotionKindDeal && kind != entity.PromotionKindEvent {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "kind",
+				Code:    config.ErrorInvalidValue,
+				Message: "must be one of: " + entity.PromotionKindDeal + ", " + entity.PromotionKindEvent,
+			})
+			return
+		}
+
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "p.kind",
+			Type:   "eq",
+			Value:  kind,
+		})
+	}
+
+	filters, ok := h.locationFilters(ctx)
+	if !ok {
+		return
+	}
+	req.Filters = append(req.Filters, filters...)
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "p.starts_at",
+		Order:  "asc",
+	})
+
+	promotions, err := h.UseCase.PromotionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting promotions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotions)
+}
+
+// UpdatePromotion godoc
+// @Router /business/promotions [put]
+// @Summary Update a promotion of your business
+// @Description Change a deal or event, e.g. to extend it. The new period must not have ended.
+// @Security BearerAuth
+// @Tags promotion
+// @Accept  json
+// @Produce  json
+// @Param promotion body entity.UpdatePromotionRequest true "Promotion object"
+// @Success 200 {object} entity.Promotion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) UpdatePromotion(ctx *gin.Context) {
+	var body entity.UpdatePromotionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	promotion, err := h.UseCase.PromotionRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting promotion") {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, promotion.BusinessID) {
+		return
+	}
+
+	promotion.Kind = body.Kind
+	promotion.Title = body.Title
+	promotion.Description = body.Description
+	promotion.Weekdays = body.Weekdays
+
+	if !h.preparePromotion(ctx, &promotion, body.StartsAt, body.EndsAt) {
+		return
+	}
+
+	promotion, err = h.UseCase.PromotionRepo.Update(ctx, promotion)
+	if h.HandleDbError(ctx, err, "Error updating promotion") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotion)
+}
+
+// DeletePromotion godoc
+// @Router /business/promotions/{id} [delete]
+// @Summary Delete a promotion of your business
+// @Description Delete a deal or event, e.g. to call it off early
+// @Security BearerAuth
+// @Tags promotion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) DeletePromotion(ctx *gin.Context) {
+	promotion, err := h.UseCase.PromotionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting promotion") {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, promotion.BusinessID) {
+		return
+	}
+
+	err = h.UseCase.PromotionRepo.Delete(ctx, entity.Id{ID: promotion.ID})
+	if h.HandleDbError(ctx, err, "Error deleting promotion") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Promotion deleted successfully",
+	})
+}
@@ -0,0 +1,523 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/etc"
+)
+
+// upvotedIDs returns the IDs of the questions or answers the current principal upvoted, out of ids.
+func (h *Handler) upvotedIDs(ctx *gin.Context, target string, ids []string) map[string]bool {
+	principal := GetPrincipal(ctx)
+	if !principal.IsAuthenticated() || len(ids) == 0 {
+		return nil
+	}
+
+	upvotes, err := h.UseCase.UpvoteRepo.GetList(ctx, principal.UserID, target, ids)
+	if err != nil {
+		h.Logger.Error(err, "Error getting upvotes")
+		return nil
+	}
+
+	upvoted := make(map[string]bool, len(upvotes))
+	for _, upvote := range upvotes {
+		upvoted[upvote.TargetID] = true
+	}
+
+	return upvoted
+}
+
+// setQuestionsUpvoted fills Question.Upvoted for the current principal.
+func (h *Handler) setQuestionsUpvoted(ctx *gin.Context, questions []entity.Question) {
+	ids := make([]string, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+	}
+
+	upvoted := h.upvotedIDs(ctx, entity.UpvoteTargetQuestion, ids)
+	for i := range questions {
+		questions[i].Upvoted = upvoted[questions[i].ID]
+	}
+}
+
+// setAnswersUpvoted fills Answer.Upvoted for the current principal.
+func (h *Handler) setAnswersUpvoted(ctx *gin.Context, answers []entity.Answer) {
+	ids := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		ids = append(ids, answer.ID)
+	}
+
+	upvoted := h.upvotedIDs(ctx, entity.UpvoteTargetAnswer, ids)
+	for i := range answers {
+		answers[i].Upvoted = upvoted[answers[i].ID]
+	}
+}
+
+// toggleUpvote adds the upvote of the current principal, or takes it back if it was already there.
+// It reports whether the upvote is now set.
+func (h *Handler) toggleUpvote(ctx *gin.Context, target, targetID string) (bool, error) {
+	upvote := entity.Upvote{
+		Target:   target,
+		TargetID: targetID,
+		UserID:   GetPrincipal(ctx).UserID,
+	}
+
+	rows, err := h.UseCase.UpvoteRepo.Delete(ctx, upvote)
+	if err != nil || rows.RowsEffected != 0 {
+		return false, err
+	}
+
+	return true, h.UseCase.UpvoteRepo.Create(ctx, upvote)
+}
+
+// notifyAnswer emails the user who asked a question that it got an answer.
+func (h *Handler) notifyAnswer(ctx *gin.Context, business entity.Business, question entity.Question, answer entity.Answer) {
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: question.UserID})
+	if err != nil {
+		h.Logger.Error(err, "Error getting user to email about answer")
+		return
+	}
+
+	go h.sendAnswerEmail(user.Email, etc.AnswerNotice{
+		BusinessName: business.Name,
+		Question:     question.Text,
+		Answer:       answer.Text,
+		ByOwner:      answer.ByOwner,
+	})
+}
+
+func (h *Handler) sendAnswerEmail(email string, notice etc.AnswerNotice) {
+	emailBody, err := etc.GenerateAnswerEmailBody(notice)
+	if err != nil {
+		h.Logger.Error(err, "Error generating answer email body")
+		return
+	}
+
+	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, email, "Your question about "+notice.BusinessName+" was answered", emailBody)
+	if err != nil {
+		h.Logger.Error(err, "Error sending answer email")
+	}
+}
+
+// CreateQuestion godoc
+// @Router /question [post]
+// @Summary Ask a question about a business
+// @Description Ask a question about a business. Its owner and other users can answer it, you are emailed when they do.
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param question body entity.CreateQuestionRequest true "Question object"
+// @Success 201 {object} entity.Question
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) CreateQuestion(ctx *gin.Context) {
+	var body entity.CreateQuestionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	business, ok := h.getVisibleBusiness(ctx, body.BusinessID)
+	if !ok {
+		return
+	}
+
+	question, err := h.UseCase.QuestionRepo.Create(ctx, entity.Question{
+		BusinessID: business.ID,
+		UserID:     GetPrincipal(ctx).UserID,
+		Text:       body.Text,
+	})
+	if h.HandleDbError(ctx, err, "Error creating question") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, question)
+}
+
+// GetQuestions godoc
+// @Router /question/list [get]
+// @Summary Get the questions about a business
+// @Description Get the questions about a business, most upvoted first or newest first. Set unanswered=true to get only the ones without answers.
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param business_id query string true "business_id"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param sort query string false "sort" Enums(top, newest)
+// @Param unanswered query bool false "unanswered"
+// @Success 200 {object} entity.QuestionList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetQuestions(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	sort := ctx.DefaultQuery("sort", "top")
+
+	if sort != "top" && sort != "newest" {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "sort",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be one of: top, newest",
+		})
+		return
+	}
+
+	business, ok := h.getVisibleBusiness(ctx, ctx.Query("business_id"))
+	if !ok {
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "business_id",
+		Type:   "eq",
+		Value:  business.ID,
+	})
+
+	if ctx.Query("unanswered") == "true" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "answer_count",
+			Type:   "eq",
+			Value:  "0",
+		})
+	}
+
+	if sort == "top" {
+		req.OrderBy = append(req.OrderBy, entity.OrderBy{
+			Column: "upvote_count",
+			Order:  "desc",
+		})
+	}
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "created_at",
+		Order:  "desc",
+	})
+
+	questions, err := h.UseCase.QuestionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting questions") {
+		return
+	}
+
+	h.setQuestionsUpvoted(ctx, questions.Items)
+
+	ctx.JSON(http.StatusOK, questions)
+}
+
+// GetQuestion godoc
+// @Router /question/{id} [get]
+// @Summary Get a question by ID
+// @Description Get a question about a business, its answers are listed separately
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Question ID"
+// @Success 200 {object} entity.Question
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetQuestion(ctx *gin.Context) {
+	question, err := h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting question") {
+		return
+	}
+
+	if _, ok := h.getVisibleBusiness(ctx, question.BusinessID); !ok {
+		return
+	}
+
+	questions := []entity.Question{question}
+	h.setQuestionsUpvoted(ctx, questions)
+
+	ctx.JSON(http.StatusOK, questions[0])
+}
+
+// DeleteQuestion godoc
+// @Router /question/{id} [delete]
+// @Summary Delete your question
+// @Description Delete a question you asked along with its answers
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Question ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) DeleteQuestion(ctx *gin.Context) {
+	var req entity.Id
+
+	req.ID = ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceQuestion, req.ID) {
+		return
+	}
+
+	err := h.UseCase.QuestionRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting question") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Question deleted successfully",
+	})
+}
+
+// UpvoteQuestion godoc
+// @Router /question/{id}/upvote [post]
+// @Summary Upvote a question
+// @Description Upvote a question to show you want it answered too. Calling it again takes the upvote back.
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Question ID"
+// @Success 200 {object} entity.UpvoteResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) UpvoteQuestion(ctx *gin.Context) {
+	question, err := h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting question") {
+		return
+	}
+
+	if question.UserID == GetPrincipal(ctx).UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "You can't upvote your own question", http.StatusForbidden)
+		return
+	}
+
+	if _, ok := h.getVisibleBusiness(ctx, question.BusinessID); !ok {
+		return
+	}
+
+	upvoted, err := h.toggleUpvote(ctx, entity.UpvoteTargetQuestion, question.ID)
+	if h.HandleDbError(ctx, err, "Error upvoting question") {
+		return
+	}
+
+	question, err = h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: question.ID})
+	if h.HandleDbError(ctx, err, "Error getting question") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.UpvoteResponse{
+		Upvoted:     upvoted,
+		UpvoteCount: question.UpvoteCount,
+	})
+}
+
+// CreateAnswer godoc
+// @Router /question/{id}/answers [post]
+// @Summary Answer a question
+// @Description Answer a question about a business. The user who asked it is emailed.
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Question ID"
+// @Param answer body entity.CreateAnswerRequest true "Answer object"
+// @Success 201 {object} entity.Answer
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) CreateAnswer(ctx *gin.Context) {
+	var body entity.CreateAnswerRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	question, err := h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting question") {
+		return
+	}
+
+	business, ok := h.getVisibleBusiness(ctx, question.BusinessID)
+	if !ok {
+		return
+	}
+
+	answer, err := h.UseCase.AnswerRepo.Create(ctx, entity.Answer{
+		QuestionID: question.ID,
+		UserID:     GetPrincipal(ctx).UserID,
+		Text:       body.Text,
+	})
+	if h.HandleDbError(ctx, err, "Error creating answer") {
+		return
+	}
+
+	if answer.UserID != question.UserID {
+		h.notifyAnswer(ctx, business, question, answer)
+	}
+
+	ctx.JSON(http.StatusCreated, answer)
+}
+
+// GetAnswers godoc
+// @Router /question/{id}/answers [get]
+// @Summary Get the answers to a question
+// @Description Get the answers to a question, the accepted one first and then the most upvoted
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Question ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.AnswerList
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetAnswers(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	question, err := h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting question") {
+		return
+	}
+
+	if _, ok := h.getVisibleBusiness(ctx, question.BusinessID); !ok {
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "a.question_id",
+		Type:   "eq",
+		Value:  question.ID,
+	})
+	req.OrderBy = append(req.OrderBy,
+		entity.OrderBy{
+			Column: "COALESCE(a.id = q.accepted_answer_id, false)",
+			Order:  "desc",
+		},
+		entity.OrderBy{
+			Column: "a.upvote_count",
+			Order:  "desc",
+		},
+		entity.OrderBy{
+			Column: "a.created_at",
+			Order:  "asc",
+		},
+	)
+
+	answers, err := h.UseCase.AnswerRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting answers") {
+		return
+	}
+
+	h.setAnswersUpvoted(ctx, answers.Items)
+
+	ctx.JSON(http.StatusOK, answers)
+}
+
+// DeleteAnswer godoc
+// @Router /question/answers/{id} [delete]
+// @Summary Delete your answer
+// @Description Delete an answer you wrote
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Answer ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) DeleteAnswer(ctx *gin.Context) {
+	var req entity.Id
+
+	req.ID = ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceAnswer, req.ID) {
+		return
+	}
+
+	err := h.UseCase.AnswerRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting answer") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Answer deleted successfully",
+	})
+}
+
+// UpvoteAnswer godoc
+// @Router /question/answers/{id}/upvote [post]
+// @Summary Upvote an answer
+// @Description Upvote a helpful answer. Calling it again takes the upvote back.
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Answer ID"
+// @Success 200 {object} entity.UpvoteResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) UpvoteAnswer(ctx *gin.Context) {
+	answer, err := h.UseCase.AnswerRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting answer") {
+		return
+	}
+
+	if answer.UserID == GetPrincipal(ctx).UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "You can't upvote your own answer", http.StatusForbidden)
+		return
+	}
+
+	upvoted, err := h.toggleUpvote(ctx, entity.UpvoteTargetAnswer, answer.ID)
+	if h.HandleDbError(ctx, err, "Error upvoting answer") {
+		return
+	}
+
+	answer, err = h.UseCase.AnswerRepo.GetSingle(ctx, entity.Id{ID: answer.ID})
+	if h.HandleDbError(ctx, err, "Error getting answer") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.UpvoteResponse{
+		Upvoted:     upvoted,
+		UpvoteCount: answer.UpvoteCount,
+	})
+}
+
+// AcceptAnswer godoc
+// @Router /question/answers/{id}/accept [post]
+// @Summary Accept an answer to a question about your business
+// @Description Mark an answer as the accepted one, it is listed first. It replaces the answer accepted before.
+// @Security BearerAuth
+// @Tags question
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Answer ID"
+// @Success 200 {object} entity.Answer
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) AcceptAnswer(ctx *gin.Context) {
+	answer, err := h.UseCase.AnswerRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting answer") {
+		return
+	}
+
+	question, err := h.UseCase.QuestionRepo.GetSingle(ctx, entity.Id{ID: answer.QuestionID})
+	if h.HandleDbError(ctx, err, "Error getting question") {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, question.BusinessID) {
+		return
+	}
+
+	err = h.UseCase.QuestionRepo.SetAcceptedAnswer(ctx, question.ID, answer.ID)
+	if h.HandleDbError(ctx, err, "Error accepting answer") {
+		return
+	}
+
+	answer.Accepted = true
+
+	ctx.JSON(http.StatusOK, answer)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+const (
+	testQuestionID = "eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee"
+	testAnswerID   = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+)
+
+// fakeQuestionRepo serves one question and keeps the answer accepted for it.
+type fakeQuestionRepo struct {
+	usecase.QuestionRepoI
+	question entity.Question
+}
+
+func (r *fakeQuestionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Question, error) {
+	if req.ID != r.question.ID {
+		return entity.Question{}, pgx.ErrNoRows
+	}
+	return r.question, nil
+}
+
+func (r *fakeQuestionRepo) SetAcceptedAnswer(ctx context.Context, questionID, answerID string) error {
+	r.question.AcceptedAnswerID = answerID
+	return nil
+}
+
+type fakeAnswerRepo struct {
+	usecase.AnswerRepoI
+	answer entity.Answer
+}
+
+func (r *fakeAnswerRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Answer, error) {
+	if req.ID != r.answer.ID {
+		return entity.Answer{}, pgx.ErrNoRows
+	}
+	return r.answer, nil
+}
+
+// TestAcceptAnswer_OnlyTheBusinessOwnerAccepts checks that the owner of the business picks the accepted answer,
+// not the user who asked or answered the question.
+func TestAcceptAnswer_OnlyTheBusinessOwnerAccepts(t *testing.T) {
+	const askerID = "12121212-1212-1212-1212-121212121212"
+
+	tests := []struct {
+		name      string
+		principal entity.Principal
+		status    int
+	}{
+		{"business owner", entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, http.StatusOK},
+		{"admin", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, http.StatusOK},
+		{"asker", entity.Principal{UserID: askerID, UserRole: entity.UserRoleUser}, http.StatusForbidden},
+		{"answerer", entity.Principal{UserID: spoofedUserID, UserRole: entity.UserRoleUser}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions := &fakeQuestionRepo{question: entity.Question{
+				ID:         testQuestionID,
+				BusinessID: testBusinessID,
+				UserID:     askerID,
+			}}
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{
+					BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: {
+						ID:        testBusinessID,
+						Status:    entity.ContentStatusPublished,
+						CreatedBy: testUserID,
+					}}},
+					QuestionRepo: questions,
+					AnswerRepo: &fakeAnswerRepo{answer: entity.Answer{
+						ID:         testAnswerID,
+						QuestionID: testQuestionID,
+						UserID:     spoofedUserID,
+					}},
+				},
+			}
+
+			ctx, w := newPrincipalContext(tt.principal, http.MethodPost, "")
+			ctx.Params = gin.Params{{Key: "id", Value: testAnswerID}}
+			h.AcceptAnswer(ctx)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+
+			accepted := questions.question.AcceptedAnswerID == testAnswerID
+			if accepted != (tt.status == http.StatusOK) {
+				t.Fatalf("accepted answer = %q after a %d", questions.question.AcceptedAnswerID, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/internal/entity"
+)
+
+// CreateReport godoc
+// @Router /report [post]
+// @Summary Report content
+// @Description Report an abusive business, review, review reply or photo. Each user can report a piece of content once.
+// @Security BearerAuth
+// @Tags report
+// @Accept  json
+// @Produce  json
+// @Param report body entity.CreateReportRequest true "Report object"
+// @Success 201 {object} entity.Report
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) CreateReport(ctx *gin.Context) {
+	var body entity.CreateReportRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	owner, err := h.contentOwner(ctx, body.TargetType, body.TargetID)
+	if h.HandleDbError(ctx, err, "Error getting reported "+body.TargetType) {
+		return
+	}
+
+	report, err := h.UseCase.ReportRepo.Create(ctx, entity.Report{
+		TargetType: body.TargetType,
+		TargetID:   body.TargetID,
+		ReporterID: GetPrincipal(ctx).UserID,
+		ReasonCode: body.ReasonCode,
+		Details:    body.Details,
+		Status:     entity.ModerationStatusOpen,
+	})
+	if h.HandleDbError(ctx, err, "Error creating report") {
+		return
+	}
+
+	// Put the content in the moderation queue, or add the report to its unresolved case
+	item, err := h.UseCase.ModerationCaseRepo.Open(ctx, entity.ModerationCase{
+		TargetType:    body.TargetType,
+		TargetID:      body.TargetID,
+		TargetOwnerID: owner,
+		ReportCount:   1,
+		ReasonCode:    body.ReasonCode,
+	})
+	if h.HandleDbError(ctx, err, "Error opening moderation case") {
+		return
+	}
+
+	report.CaseID = item.ID
+	report.Status = item.Status
+
+	_, err = h.UseCase.ReportRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: report.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "case_id", Value: report.CaseID},
+			{Column: "status", Value: report.Status},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error updating report") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
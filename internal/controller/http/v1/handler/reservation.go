@@ -0,0 +1,708 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/etc"
+)
+
+// reservationSettings gets the reservation settings of a business, the disabled defaults if it never set them up.
+func (h *Handler) reservationSettings(ctx *gin.Context, businessID string) (entity.ReservationSettings, error) {
+	settings, err := h.UseCase.ReservationSettingsRepo.GetSingle(ctx, entity.Id{ID: businessID})
+	if err == pgx.ErrNoRows {
+		return entity.DefaultReservationSettings(businessID), nil
+	}
+
+	return settings, err
+}
+
+// parseSlotTime parses the RFC 3339 start time of a reservation.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) parseSlotTime(ctx *gin.Context, value string) (time.Time, bool) {
+	startsAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "starts_at",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be an RFC 3339 time, e.g. 2024-05-01T19:30:00+05:00",
+		})
+		return time.Time{}, false
+	}
+
+	return startsAt, true
+}
+
+// checkSlot checks that a party can book the slot starting at startsAt, leaving capacity to the repo.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) checkSlot(ctx *gin.Context, business entity.Business, settings entity.ReservationSettings, startsAt time.Time, partySize int) bool {
+	if !settings.Enabled {
+		h.ReturnError(ctx, config.ErrorBadRequest, "This business doesn't take reservations", http.StatusBadRequest)
+		return false
+	}
+
+	if partySize > settings.MaxPartySize {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "party_size",
+			Code:    config.ErrorInvalidValue,
+			Message: fmt.Sprintf("must be at most %d", settings.MaxPartySize),
+		})
+		return false
+	}
+
+	now := time.Now()
+
+	var message string
+	switch {
+	case !business.OpeningHours.IsSlot(startsAt, settings.SlotMinutes):
+		message = "must be the start of a reservation slot, see the availability of the business"
+	case startsAt.Before(now.Add(time.Duration(settings.MinNoticeMinutes) * time.Minute)):
+		message = fmt.Sprintf("must be at least %d minutes from now", settings.MinNoticeMinutes)
+	case startsAt.After(now.AddDate(0, 0, settings.MaxDaysAhead)):
+		message = fmt.Sprintf("must be at most %d days from now", settings.MaxDaysAhead)
+	}
+
+	if message != "" {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "starts_at",
+			Code:    config.ErrorInvalidValue,
+			Message: message,
+		})
+		return false
+	}
+
+	return true
+}
+
+// notifyReservation emails the user who made a reservation that it was confirmed, rescheduled or cancelled.
+func (h *Handler) notifyReservation(ctx *gin.Context, business entity.Business, reservation entity.Reservation, status string) {
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: reservation.UserID})
+	if err != nil {
+		h.Logger.Error(err, "Error getting user to email about reservation")
+		return
+	}
+
+	startsAt, _ := time.Parse(time.RFC3339, reservation.StartsAt)
+	if loc, err := time.LoadLocation(business.OpeningHours.Timezone); err == nil {
+		startsAt = startsAt.In(loc)
+	}
+
+	go h.sendReservationEmail(user.Email, etc.ReservationNotice{
+		BusinessName: business.Name,
+		StartsAt:     startsAt.Format("Mon, 02 Jan 2006 15:04 MST"),
+		PartySize:    reservation.PartySize,
+		Status:       status,
+	})
+}
+
+func (h *Handler) sendReservationEmail(email string, notice etc.ReservationNotice) {
+	emailBody, err := etc.GenerateReservationEmailBody(notice)
+	if err != nil {
+		h.Logger.Error(err, "Error generating reservation email body")
+		return
+	}
+
+	err = etc.SendEmail(h.Config.Gmail.Host, h.Config.Gmail.Port, h.Config.Gmail.Email, h.Config.Gmail.EmailPass, email, "Your reservation at "+notice.BusinessName, emailBody)
+	if err != nil {
+		h.Logger.Error(err, "Error sending reservation email")
+	}
+}
+
+// SetOpeningHours godoc
+// @Router /business/{id}/hours [put]
+// @Summary Set the opening hours of a business
+// @Description Replace the weekly schedule of a business. Periods are in its timezone, a day can have several.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param hours body entity.OpeningHours true "Opening hours"
+// @Success 200 {object} entity.Business
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) SetOpeningHours(ctx *gin.Context) {
+	var body entity.OpeningHours
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	if message := body.Check(); message != "" {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "periods",
+			Code:    config.ErrorInvalidValue,
+			Message: message,
+		})
+		return
+	}
+
+	if body.Periods == nil {
+		body.Periods = []entity.OpeningPeriod{}
+	}
+
+	err := h.UseCase.BusinessRepo.SetOpeningHours(ctx, businessID, body, GetPrincipal(ctx).UserID)
+	if h.HandleDbError(ctx, err, "Error setting opening hours") {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: businessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, business)
+}
+
+// GetReservationSettings godoc
+// @Router /business/{id}/reservation-settings [get]
+// @Summary Get the reservation settings of a business
+// @Description Get how a business takes reservations. Businesses that never set them up don't take reservations.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Success 200 {object} entity.ReservationSettings
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetReservationSettings(ctx *gin.Context) {
+	business, ok := h.getVisibleBusiness(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	settings, err := h.reservationSettings(ctx, business.ID)
+	if h.HandleDbError(ctx, err, "Error getting reservation settings") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateReservationSettings godoc
+// @Router /business/{id}/reservation-settings [put]
+// @Summary Update the reservation settings of a business
+// @Description Set the slot length, guests per slot and booking window of a business. Reservations can only be enabled once it has opening hours.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param settings body entity.ReservationSettings true "Reservation settings"
+// @Success 200 {object} entity.ReservationSettings
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) UpdateReservationSettings(ctx *gin.Context) {
+	var body entity.ReservationSettings
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	body.BusinessID = ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, body.BusinessID) {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	if body.Enabled && len(business.OpeningHours.Periods) == 0 {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Set the opening hours of the business before taking reservations", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.UseCase.ReservationSettingsRepo.Upsert(ctx, body)
+	if h.HandleDbError(ctx, err, "Error updating reservation settings") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// GetAvailability godoc
+// @Router /business/{id}/availability [get]
+// @Summary Get the reservation slots of a business
+// @Description Get the slots of a business on a date in its timezone with how many guests each still has room for. Slots too soon to book are left out.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param date query string true "date, e.g. 2024-05-01"
+// @Success 200 {object} entity.ReservationAvailability
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetAvailability(ctx *gin.Context) {
+	business, ok := h.getVisibleBusiness(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	settings, err := h.reservationSettings(ctx, business.ID)
+	if h.HandleDbError(ctx, err, "Error getting reservation settings") {
+		return
+	}
+
+	if !settings.Enabled {
+		h.ReturnError(ctx, config.ErrorBadRequest, "This business doesn't take reservations", http.StatusBadRequest)
+		return
+	}
+
+	date := ctx.Query("date")
+
+	slots, err := business.OpeningHours.Slots(date, settings.SlotMinutes)
+	if err != nil {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "date",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be a date, e.g. 2024-05-01",
+		})
+		return
+	}
+
+	response := entity.ReservationAvailability{
+		BusinessID: business.ID,
+		Date:       date,
+		Timezone:   business.OpeningHours.Timezone,
+		Slots:      []entity.ReservationSlot{},
+	}
+
+	if len(slots) == 0 {
+		ctx.JSON(http.StatusOK, response)
+		return
+	}
+
+	booked, err := h.UseCase.ReservationRepo.CountBooked(ctx, business.ID,
+		slots[0].UTC().Format(time.RFC3339),
+		slots[len(slots)-1].Add(time.Duration(settings.SlotMinutes)*time.Minute).UTC().Format(time.RFC3339))
+	if h.HandleDbError(ctx, err, "Error getting reservations") {
+		return
+	}
+
+	earliest := time.Now().Add(time.Duration(settings.MinNoticeMinutes) * time.Minute)
+	latest := time.Now().AddDate(0, 0, settings.MaxDaysAhead)
+
+	for _, slot := range slots {
+		if slot.Before(earliest) || slot.After(latest) {
+			continue
+		}
+
+		guests := booked[slot.UTC().Format(time.RFC3339)]
+		response.Slots = append(response.Slots, entity.ReservationSlot{
+			StartsAt:  slot.Format(time.RFC3339),
+			Booked:    guests,
+			Available: max(settings.Capacity-guests, 0),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// CreateReservation godoc
+// @Router /reservation [post]
+// @Summary Make a reservation
+// @Description Book a slot of a business for a party. A confirmation is emailed to you.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param reservation body entity.CreateReservationRequest true "Reservation object"
+// @Success 201 {object} entity.Reservation
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) CreateReservation(ctx *gin.Context) {
+	var body entity.CreateReservationRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	startsAt, ok := h.parseSlotTime(ctx, body.StartsAt)
+	if !ok {
+		return
+	}
+
+	business, ok := h.getVisibleBusiness(ctx, body.BusinessID)
+	if !ok {
+		return
+	}
+
+	settings, err := h.reservationSettings(ctx, business.ID)
+	if h.HandleDbError(ctx, err, "Error getting reservation settings") {
+		return
+	}
+
+	if !h.checkSlot(ctx, business, settings, startsAt, body.PartySize) {
+		return
+	}
+
+	reservation, err := h.UseCase.ReservationRepo.Create(ctx, entity.Reservation{
+		BusinessID:   business.ID,
+		BusinessName: business.Name,
+		UserID:       GetPrincipal(ctx).UserID,
+		PartySize:    body.PartySize,
+		StartsAt:     startsAt.UTC().Format(time.RFC3339),
+		Note:         body.Note,
+	}, settings.Capacity)
+	if err == entity.ErrSlotFull {
+		h.ReturnError(ctx, config.ErrorConflict, "This slot is fully booked, pick another time", http.StatusConflict)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error creating reservation") {
+		return
+	}
+
+	h.notifyReservation(ctx, business, reservation, entity.ReservationStatusConfirmed)
+
+	ctx.JSON(http.StatusCreated, reservation)
+}
+
+// GetReservations godoc
+// @Router /reservation/list [get]
+// @Summary Get your reservations
+// @Description Get your reservations, latest first, or only the upcoming ones soonest first.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param status query string false "status" Enums(confirmed, cancelled)
+// @Param upcoming query bool false "upcoming"
+// @Success 200 {object} entity.ReservationList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetReservations(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	status := ctx.Query("status")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "r.user_id",
+		Type:   "eq",
+		Value:  GetPrincipal(ctx).UserID,
+	})
+
+	if status != "" {
+		if status != entity.ReservationStatusConfirmed && status != entity.ReservationStatusCancelled {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "status",
+				Code:    config.ErrorInvalidValue,
+				Message: "must be one of: " + entity.ReservationStatusConfirmed + ", " + entity.ReservationStatusCancelled,
+			})
+			return
+		}
+
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "r.status",
+			Type:   "eq",
+			Value:  status,
+		})
+	}
+
+	order := "desc"
+	if ctx.Query("upcoming") == "true" {
+		order = "asc"
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "r.starts_at",
+			Type:   "gte",
+			Value:  time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "r.starts_at",
+		Order:  order,
+	})
+
+	reservations, err := h.UseCase.ReservationRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting reservations") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reservations)
+}
+
+// RescheduleReservation godoc
+// @Router /reservation [put]
+// @Summary Reschedule a reservation
+// @Description Move an upcoming reservation to another slot of the same business or change its party size.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param reservation body entity.RescheduleReservationRequest true "Reservation object"
+// @Success 200 {object} entity.Reservation
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) RescheduleReservation(ctx *gin.Context) {
+	var body entity.RescheduleReservationRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	startsAt, ok := h.parseSlotTime(ctx, body.StartsAt)
+	if !ok {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceReservation, body.ID) {
+		return
+	}
+
+	reservation, err := h.UseCase.ReservationRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting reservation") {
+		return
+	}
+
+	if reservation.Status != entity.ReservationStatusConfirmed {
+		h.ReturnError(ctx, config.ErrorConflict, "This reservation is cancelled", http.StatusConflict)
+		return
+	}
+
+	if current, _ := time.Parse(time.RFC3339, reservation.StartsAt); current.Before(time.Now()) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Past reservations can't be changed", http.StatusBadRequest)
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: reservation.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	settings, err := h.reservationSettings(ctx, business.ID)
+	if h.HandleDbError(ctx, err, "Error getting reservation settings") {
+		return
+	}
+
+	if !h.checkSlot(ctx, business, settings, startsAt, body.PartySize) {
+		return
+	}
+
+	reservation.StartsAt = startsAt.UTC().Format(time.RFC3339)
+	reservation.PartySize = body.PartySize
+
+	err = h.UseCase.ReservationRepo.Reschedule(ctx, reservation, settings.Capacity)
+	if err == entity.ErrSlotFull {
+		h.ReturnError(ctx, config.ErrorConflict, "This slot is fully booked, pick another time", http.StatusConflict)
+		return
+	}
+	if err == entity.ErrReservationCancelled {
+		h.ReturnError(ctx, config.ErrorConflict, "This reservation is cancelled", http.StatusConflict)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error rescheduling reservation") {
+		return
+	}
+
+	reservation, err = h.UseCase.ReservationRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting reservation") {
+		return
+	}
+
+	h.notifyReservation(ctx, business, reservation, "rescheduled")
+
+	ctx.JSON(http.StatusOK, reservation)
+}
+
+// CancelReservation godoc
+// @Router /reservation/{id}/cancel [post]
+// @Summary Cancel a reservation
+// @Description Cancel an upcoming reservation. It can be cancelled by the user who made it or the owner of the business, the user is emailed either way.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) CancelReservation(ctx *gin.Context) {
+	reservation, err := h.UseCase.ReservationRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting reservation") {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: reservation.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+	if reservation.UserID != principal.UserID && business.CreatedBy != principal.UserID && !principal.IsAdmin() {
+		h.ReturnError(ctx, config.ErrorForbidden, "You don't have access to this "+entity.ResourceReservation, http.StatusForbidden)
+		return
+	}
+
+	if startsAt, _ := time.Parse(time.RFC3339, reservation.StartsAt); startsAt.Before(time.Now()) {
+		h.ReturnError(ctx, config.ErrorBadRequest, "Past reservations can't be cancelled", http.StatusBadRequest)
+		return
+	}
+
+	// only a confirmed reservation is cancelled, so two cancellations don't both email the user
+	cancelled, err := h.UseCase.ReservationRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{
+			{Column: "id", Type: "eq", Value: reservation.ID},
+			{Column: "status", Type: "eq", Value: entity.ReservationStatusConfirmed},
+		},
+		Items: []entity.UpdateFieldItem{
+			{Column: "status", Value: entity.ReservationStatusCancelled},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error cancelling reservation") {
+		return
+	}
+
+	if cancelled.RowsEffected == 0 {
+		h.ReturnError(ctx, config.ErrorConflict, "This reservation is already cancelled", http.StatusConflict)
+		return
+	}
+
+	h.notifyReservation(ctx, business, reservation, entity.ReservationStatusCancelled)
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Reservation cancelled successfully",
+	})
+}
+
+// GetReservationCalendar godoc
+// @Router /business/{id}/calendar [get]
+// @Summary Get the reservation calendar of a business
+// @Description Get the slots and confirmed reservations of your business day by day, in its timezone. It starts today by default and covers up to 31 days.
+// @Security BearerAuth
+// @Tags reservation
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param from query string false "first date, e.g. 2024-05-01"
+// @Param days query number false "days, 7 by default"
+// @Success 200 {object} entity.ReservationCalendar
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) GetReservationCalendar(ctx *gin.Context) {
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "7"))
+	if err != nil || days < 1 || days > config.MaxCalendarDays {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "days",
+			Code:    config.ErrorInvalidValue,
+			Message: fmt.Sprintf("must be a number from 1 to %d", config.MaxCalendarDays),
+		})
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: businessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	settings, err := h.reservationSettings(ctx, businessID)
+	if h.HandleDbError(ctx, err, "Error getting reservation settings") {
+		return
+	}
+
+	loc, err := time.LoadLocation(business.OpeningHours.Timezone)
+	if h.HandleDbError(ctx, err, "Error loading business timezone") {
+		return
+	}
+
+	from, err := time.ParseInLocation("2006-01-02", ctx.DefaultQuery("from", time.Now().In(loc).Format("2006-01-02")), loc)
+	if err != nil {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "from",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be a date, e.g. 2024-05-01",
+		})
+		return
+	}
+
+	to := from.AddDate(0, 0, days)
+
+	booked, err := h.UseCase.ReservationRepo.CountBooked(ctx, businessID, from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	if h.HandleDbError(ctx, err, "Error getting reservations") {
+		return
+	}
+
+	reservations, err := h.UseCase.ReservationRepo.GetList(ctx, entity.GetListFilter{
+		Limit: config.MaxCalendarReservations,
+		Filters: []entity.Filter{
+			{Column: "r.business_id", Type: "eq", Value: businessID},
+			{Column: "r.status", Type: "eq", Value: entity.ReservationStatusConfirmed},
+			{Column: "r.starts_at", Type: "gte", Value: from.UTC().Format(time.RFC3339)},
+			{Column: "r.starts_at", Type: "lt", Value: to.UTC().Format(time.RFC3339)},
+		},
+		OrderBy: []entity.OrderBy{{Column: "r.starts_at", Order: "asc"}},
+	})
+	if h.HandleDbError(ctx, err, "Error getting reservations") {
+		return
+	}
+
+	byDate := map[string][]entity.Reservation{}
+	for _, reservation := range reservations.Items {
+		startsAt, _ := time.Parse(time.RFC3339, reservation.StartsAt)
+		date := startsAt.In(loc).Format("2006-01-02")
+		byDate[date] = append(byDate[date], reservation)
+	}
+
+	calendar := entity.ReservationCalendar{
+		BusinessID: businessID,
+		Timezone:   business.OpeningHours.Timezone,
+	}
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+
+		slots, _ := business.OpeningHours.Slots(date, settings.SlotMinutes)
+
+		calendarDay := entity.ReservationCalendarDay{
+			Date:         date,
+			Slots:        []entity.ReservationSlot{},
+			Reservations: byDate[date],
+		}
+
+		if calendarDay.Reservations == nil {
+			calendarDay.Reservations = []entity.Reservation{}
+		}
+
+		for _, slot := range slots {
+			guests := booked[slot.UTC().Format(time.RFC3339)]
+			calendarDay.Slots = append(calendarDay.Slots, entity.ReservationSlot{
+				StartsAt:  slot.Format(time.RFC3339),
+				Booked:    guests,
+				Available: max(settings.Capacity-guests, 0),
+			})
+		}
+
+		calendar.Days = append(calendar.Days, calendarDay)
+	}
+
+	ctx.JSON(http.StatusOK, calendar)
+}
@@ -0,0 +1,522 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/contentfilter"
+)
+
+// reviewOrderBy maps the accepted sort orders of the review list to their ORDER BY columns.
+var reviewOrderBy = map[string][]entity.OrderBy{
+	entity.ReviewSortNewest: {
+		{Column: "r.created_at", Order: "desc"},
+	},
+	entity.ReviewSortMostHelpful: {
+		{Column: "r.useful_count", Order: "desc"},
+		{Column: "r.funny_count + r.cool_count", Order: "desc"},
+		{Column: "r.created_at", Order: "desc"},
+	},
+	entity.ReviewSortRatingHigh: {
+		{Column: "r.rating", Order: "desc"},
+		{Column: "r.created_at", Order: "desc"},
+	},
+	entity.ReviewSortRatingLow: {
+		{Column: "r.rating", Order: "asc"},
+		{Column: "r.created_at", Order: "desc"},
+	},
+}
+
+// setMyVotes fills Review.MyVotes with the votes the current principal cast on the given reviews.
+func (h *Handler) setMyVotes(ctx *gin.Context, reviews []entity.Review) {
+	principal := GetPrincipal(ctx)
+	if !principal.IsAuthenticated() || len(reviews) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		ids = append(ids, review.ID)
+	}
+
+	votes, err := h.UseCase.ReviewVoteRepo.GetList(ctx, principal.UserID, ids)
+	if err != nil {
+		h.Logger.Error(err, "Error getting review votes")
+		return
+	}
+
+	byReview := make(map[string][]string, len(votes))
+	for _, vote := range votes {
+		byReview[vote.ReviewID] = append(byReview[vote.ReviewID], vote.VoteType)
+	}
+
+	for i := range reviews {
+		reviews[i].MyVotes = byReview[reviews[i].ID]
+	}
+}
+
+// CreateReview godoc
+// @Router /review [post]
+// @Summary Create a review
+// @Description Review a business. A user can review a business once, and never their own business.
+// @Description Reviews flagged by the content filter are created as pending until a moderator looks at them.
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param review body entity.CreateReviewRequest true "Review object"
+// @Success 201 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) CreateReview(ctx *gin.Context) {
+	var body entity.CreateReviewRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: body.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	// Reviews of a merged business were moved to the one it was merged into, new ones go there too
+	if business.MergedInto != "" {
+		h.ReturnError(ctx, config.ErrorConflict, "Business was merged into "+business.MergedInto+", review that one instead", http.StatusConflict)
+		return
+	}
+
+	if !h.checkBusinessVisible(ctx, business) {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+	if business.CreatedBy == principal.UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "You can't review your own business", http.StatusForbidden)
+		return
+	}
+
+	result, err := h.ReviewFilter.Screen(ctx, contentfilter.Content{AuthorID: principal.UserID, Text: body.Text})
+	if h.HandleDbError(ctx, err, "Error screening review") {
+		return
+	}
+
+	status := entity.ContentStatusPublished
+	if result.Flagged() {
+		status = entity.ContentStatusPending
+	}
+
+	review, err := h.UseCase.ReviewRepo.Create(ctx, entity.Review{
+		BusinessID:  body.BusinessID,
+		UserID:      principal.UserID,
+		Rating:      body.Rating,
+		Text:        body.Text,
+		Attachments: body.Attachments,
+		Status:      status,
+		TextHash:    contentfilter.Fingerprint(body.Text),
+	})
+	if h.HandleDbError(ctx, err, "Error creating review") {
+		return
+	}
+
+	if result.Flagged() {
+		err = h.queueFlaggedContent(ctx, entity.ReportTargetReview, review.ID, principal.UserID, result)
+		if h.HandleDbError(ctx, err, "Error queueing review for moderation") {
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusCreated, review)
+}
+
+// GetReview godoc
+// @Router /review/{id} [get]
+// @Summary Get a review by ID
+// @Description Get a review by ID, with the owner's reply
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) GetReview(ctx *gin.Context) {
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+	if review.Status != entity.ContentStatusPublished && review.UserID != principal.UserID && !principal.IsAdmin() {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return
+	}
+
+	if _, ok := h.getVisibleBusiness(ctx, review.BusinessID); !ok {
+		return
+	}
+
+	reviews := []entity.Review{review}
+	h.setMyVotes(ctx, reviews)
+
+	ctx.JSON(http.StatusOK, reviews[0])
+}
+
+// GetReviews godoc
+// @Router /review/list [get]
+// @Summary Get a list of reviews
+// @Description Get published reviews of a business or a user. Sort by newest (default), most_helpful, rating_high or rating_low.
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param business_id query string false "business_id"
+// @Param user_id query string false "user_id"
+// @Param rating query number false "rating"
+// @Param sort query string false "sort"
+// @Success 200 {object} entity.ReviewList
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetReviews(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+	sort := ctx.DefaultQuery("sort", entity.ReviewSortNewest)
+
+	orderBy, ok := reviewOrderBy[sort]
+	if !ok {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "sort",
+			Code:    config.ErrorInvalidValue,
+			Message: "must be one of: newest, most_helpful, rating_high, rating_low",
+		})
+		return
+	}
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.OrderBy = orderBy
+	// reviews of a hidden or pending business are hidden with it
+	req.Filters = append(req.Filters,
+		entity.Filter{
+			Column: "r.status",
+			Type:   "eq",
+			Value:  entity.ContentStatusPublished,
+		},
+		entity.Filter{
+			Column: "b.status",
+			Type:   "eq",
+			Value:  entity.ContentStatusPublished,
+		},
+	)
+
+	for _, column := range []string{"business_id", "user_id", "rating"} {
+		if value := ctx.Query(column); value != "" {
+			req.Filters = append(req.Filters, entity.Filter{
+				Column: "r." + column,
+				Type:   "eq",
+				Value:  value,
+			})
+		}
+	}
+
+	reviews, err := h.UseCase.ReviewRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting reviews") {
+		return
+	}
+
+	h.setMyVotes(ctx, reviews.Items)
+
+	ctx.JSON(http.StatusOK, reviews)
+}
+
+// UpdateReview godoc
+// @Router /review [put]
+// @Summary Update a review
+// @Description Update your own review. A published review flagged by the content filter goes back to pending.
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param review body entity.UpdateReviewRequest true "Review object"
+// @Success 200 {object} entity.Review
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateReview(ctx *gin.Context) {
+	var body entity.UpdateReviewRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceReview, body.ID) {
+		return
+	}
+
+	// Admins can edit any review, screen it as its author's
+	before, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	result, err := h.ReviewFilter.Screen(ctx, contentfilter.Content{ID: before.ID, AuthorID: before.UserID, Text: body.Text})
+	if h.HandleDbError(ctx, err, "Error screening review") {
+		return
+	}
+
+	_, err = h.UseCase.ReviewRepo.Update(ctx, entity.Review{
+		ID:          body.ID,
+		Rating:      body.Rating,
+		Text:        body.Text,
+		Attachments: body.Attachments,
+		TextHash:    contentfilter.Fingerprint(body.Text),
+	})
+	if h.HandleDbError(ctx, err, "Error updating review") {
+		return
+	}
+
+	if result.Flagged() {
+		err = h.holdFlaggedContent(ctx, entity.ReportTargetReview, before.ID, before.UserID, result)
+		if h.HandleDbError(ctx, err, "Error queueing review for moderation") {
+			return
+		}
+	}
+
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, review)
+}
+
+// DeleteReview godoc
+// @Router /review/{id} [delete]
+// @Summary Delete a review
+// @Description Delete your own review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteReview(ctx *gin.Context) {
+	var req entity.Id
+
+	req.ID = ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceReview, req.ID) {
+		return
+	}
+
+	err := h.UseCase.ReviewRepo.Delete(ctx, req)
+	if h.HandleDbError(ctx, err, "Error deleting review") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Review deleted successfully",
+	})
+}
+
+// CreateReviewReply godoc
+// @Router /review/{id}/reply [post]
+// @Summary Reply to a review
+// @Description Publicly reply to a review of your business. A review has at most one reply.
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param reply body entity.ReviewReplyRequest true "Reply"
+// @Success 201 {object} entity.ReviewReply
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) CreateReviewReply(ctx *gin.Context) {
+	var body entity.ReviewReplyRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: review.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+	if business.CreatedBy != principal.UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "Only the business owner can reply to reviews", http.StatusForbidden)
+		return
+	}
+
+	if review.Status != entity.ContentStatusPublished {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return
+	}
+
+	reply, err := h.UseCase.ReviewReplyRepo.Create(ctx, entity.ReviewReply{
+		ReviewID: review.ID,
+		UserID:   principal.UserID,
+		Text:     body.Text,
+	})
+	if h.HandleDbError(ctx, err, "Error creating review reply") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reply)
+}
+
+// UpdateReviewReply godoc
+// @Router /review/{id}/reply [put]
+// @Summary Update a review reply
+// @Description Update your reply to a review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param reply body entity.ReviewReplyRequest true "Reply"
+// @Success 200 {object} entity.ReviewReply
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) UpdateReviewReply(ctx *gin.Context) {
+	var body entity.ReviewReplyRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	reviewID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceReply, reviewID) {
+		return
+	}
+
+	_, err := h.UseCase.ReviewReplyRepo.Update(ctx, entity.ReviewReply{
+		ReviewID: reviewID,
+		Text:     body.Text,
+	})
+	if h.HandleDbError(ctx, err, "Error updating review reply") {
+		return
+	}
+
+	reply, err := h.UseCase.ReviewReplyRepo.GetSingle(ctx, entity.ReviewReplySingleRequest{ReviewID: reviewID})
+	if h.HandleDbError(ctx, err, "Error getting review reply") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reply)
+}
+
+// DeleteReviewReply godoc
+// @Router /review/{id}/reply [delete]
+// @Summary Delete a review reply
+// @Description Delete your reply to a review
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 200 {object} entity.SuccessResponse
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) DeleteReviewReply(ctx *gin.Context) {
+	reviewID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceReply, reviewID) {
+		return
+	}
+
+	err := h.UseCase.ReviewReplyRepo.Delete(ctx, entity.Id{ID: reviewID})
+	if h.HandleDbError(ctx, err, "Error deleting review reply") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.SuccessResponse{
+		Message: "Review reply deleted successfully",
+	})
+}
+
+// VoteReview godoc
+// @Router /review/{id}/vote [post]
+// @Summary Vote on a review
+// @Description Toggle a useful, funny or cool vote on a review. Voting twice with the same type removes the vote.
+// @Security BearerAuth
+// @Tags review
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param vote body entity.ReviewVoteRequest true "Vote"
+// @Success 200 {object} entity.ReviewVoteResponse
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) VoteReview(ctx *gin.Context) {
+	var body entity.ReviewVoteRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	review, err := h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+	if review.UserID == principal.UserID {
+		h.ReturnError(ctx, config.ErrorForbidden, "You can't vote on your own review", http.StatusForbidden)
+		return
+	}
+
+	if review.Status != entity.ContentStatusPublished {
+		h.ReturnError(ctx, config.ErrorNotFound, "Review not found", http.StatusNotFound)
+		return
+	}
+
+	if _, ok := h.getVisibleBusiness(ctx, review.BusinessID); !ok {
+		return
+	}
+
+	vote := entity.ReviewVote{
+		ReviewID: review.ID,
+		UserID:   principal.UserID,
+		VoteType: body.VoteType,
+	}
+
+	rows, err := h.UseCase.ReviewVoteRepo.Delete(ctx, vote)
+	if h.HandleDbError(ctx, err, "Error deleting review vote") {
+		return
+	}
+
+	voted := rows.RowsEffected == 0
+	if voted {
+		err = h.UseCase.ReviewVoteRepo.Create(ctx, vote)
+		if h.HandleDbError(ctx, err, "Error creating review vote") {
+			return
+		}
+	}
+
+	review, err = h.UseCase.ReviewRepo.GetSingle(ctx, entity.Id{ID: review.ID})
+	if h.HandleDbError(ctx, err, "Error getting review") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ReviewVoteResponse{
+		VoteType:    body.VoteType,
+		Voted:       voted,
+		UsefulCount: review.UsefulCount,
+		FunnyCount:  review.FunnyCount,
+		CoolCount:   review.CoolCount,
+	})
+}
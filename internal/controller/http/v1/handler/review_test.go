@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+const (
+	testBusinessID = "77777777-7777-7777-7777-777777777777"
+	testTargetID   = "88888888-8888-8888-8888-888888888888"
+	testReviewID   = "99999999-9999-9999-9999-999999999999"
+)
+
+// fakeBusinessRepo serves businesses by ID, finds the similar ones in order and keeps the merges.
+type fakeBusinessRepo struct {
+	usecase.BusinessRepoI
+	businesses map[string]entity.Business
+	similar    []entity.DuplicateCandidate
+	merges     [][2]string
+}
+
+func (r *fakeBusinessRepo) GetSingle(ctx context.Context, req entity.BusinessSingleRequest) (entity.Business, error) {
+	business, ok := r.businesses[req.ID]
+	if !ok {
+		return entity.Business{}, pgx.ErrNoRows
+	}
+	return business, nil
+}
+
+func (r *fakeBusinessRepo) FindSimilar(ctx context.Context, name string, southWest, northEast entity.Location,
+	minSimilarity float64, limit int) ([]entity.DuplicateCandidate, error) {
+	if len(r.similar) > limit {
+		return r.similar[:limit], nil
+	}
+	return r.similar, nil
+}
+
+func (r *fakeBusinessRepo) Merge(ctx context.Context, sourceID, targetID string) (entity.MergeBusinessResult, error) {
+	r.merges = append(r.merges, [2]string{sourceID, targetID})
+	return entity.MergeBusinessResult{SourceID: sourceID, TargetID: targetID}, nil
+}
+
+type fakeReviewRepo struct {
+	usecase.ReviewRepoI
+	review entity.Review
+}
+
+func (r *fakeReviewRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Review, error) {
+	if req.ID != r.review.ID {
+		return entity.Review{}, pgx.ErrNoRows
+	}
+	return r.review, nil
+}
+
+func TestCreateReview_RejectsBusinessesThatCantBeReviewed(t *testing.T) {
+	user := entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser, SessionID: testSessionID}
+
+	tests := []struct {
+		name     string
+		business entity.Business
+		status   int
+	}{
+		{"pending", entity.Business{Status: entity.ContentStatusPending}, http.StatusNotFound},
+		{"hidden", entity.Business{Status: entity.ContentStatusHidden}, http.StatusNotFound},
+		{"merged", entity.Business{Status: entity.ContentStatusHidden, MergedInto: testTargetID}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.business.ID = testBusinessID
+			tt.business.CreatedBy = spoofedUserID
+
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{
+					BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: tt.business}},
+				},
+			}
+
+			ctx, w := newPrincipalContext(user, http.MethodPost,
+				`{"business_id": "`+testBusinessID+`", "rating": 5, "text": "Great place"}`)
+			h.CreateReview(ctx)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestVoteReview_RejectsReviewsOfHiddenBusinesses(t *testing.T) {
+	user := entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser, SessionID: testSessionID}
+
+	h := &Handler{
+		Logger: logger.New("error"),
+		Config: &config.Config{},
+		UseCase: &usecase.UseCase{
+			BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: {
+				ID:        testBusinessID,
+				Status:    entity.ContentStatusHidden,
+				CreatedBy: spoofedUserID,
+			}}},
+			ReviewRepo: &fakeReviewRepo{review: entity.Review{
+				ID:         testReviewID,
+				BusinessID: testBusinessID,
+				UserID:     spoofedUserID,
+				Status:     entity.ContentStatusPublished,
+			}},
+		},
+	}
+
+	ctx, w := newPrincipalContext(user, http.MethodPost, `{"vote_type": "useful"}`)
+	ctx.Params = gin.Params{{Key: "id", Value: testReviewID}}
+	h.VoteReview(ctx)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetReview_HiddenWithItsBusiness(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal entity.Principal
+		status    int
+	}{
+		{"anonymous", entity.Principal{}, http.StatusNotFound},
+		{"author", entity.Principal{UserID: spoofedUserID, UserRole: entity.UserRoleUser}, http.StatusNotFound},
+		{"business owner", entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, http.StatusOK},
+		{"admin", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{
+					BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: {
+						ID:        testBusinessID,
+						Status:    entity.ContentStatusHidden,
+						CreatedBy: testUserID,
+					}}},
+					ReviewRepo: &fakeReviewRepo{review: entity.Review{
+						ID:         testReviewID,
+						BusinessID: testBusinessID,
+						UserID:     spoofedUserID,
+						Status:     entity.ContentStatusPublished,
+					}},
+				},
+			}
+
+			ctx, w := newPrincipalContext(tt.principal, http.MethodGet, "")
+			ctx.Params = gin.Params{{Key: "id", Value: testReviewID}}
+			h.GetReview(ctx)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCreateReviewReply_OnlyToPublishedReviews(t *testing.T) {
+	owner := entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser, SessionID: testSessionID}
+
+	for _, status := range []string{entity.ContentStatusPending, entity.ContentStatusHidden} {
+		t.Run(status, func(t *testing.T) {
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{
+					BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: {
+						ID:        testBusinessID,
+						Status:    entity.ContentStatusPublished,
+						CreatedBy: testUserID,
+					}}},
+					ReviewRepo: &fakeReviewRepo{review: entity.Review{
+						ID:         testReviewID,
+						BusinessID: testBusinessID,
+						UserID:     spoofedUserID,
+						Status:     status,
+					}},
+				},
+			}
+
+			ctx, w := newPrincipalContext(owner, http.MethodPost, `{"text": "Thanks for coming by"}`)
+			ctx.Params = gin.Params{{Key: "id", Value: testReviewID}}
+			h.CreateReviewReply(ctx)
+
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
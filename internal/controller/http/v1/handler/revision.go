@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/contentfilter"
+)
+
+// queryVersion parses a revision number from the query.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) queryVersion(ctx *gin.Context, key string) (int, bool) {
+	version, err := strconv.Atoi(ctx.Query(key))
+	if err != nil || version < 1 {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   key,
+			Code:    config.ErrorInvalidValue,
+			Message: "must be a revision number",
+		})
+		return 0, false
+	}
+
+	return version, true
+}
+
+// GetBusinessRevisions godoc
+// @Router /business/{id}/revisions [get]
+// @Summary Get the revisions of a business
+// @Description Get the change history of a business, newest revision first. Only for its owner and admins.
+// @Security BearerAuth
+// @Tags revision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Success 200 {object} entity.BusinessRevisionList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) GetBusinessRevisions(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "business_id",
+		Type:   "eq",
+		Value:  businessID,
+	})
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "version",
+		Order:  "desc",
+	})
+
+	revisions, err := h.UseCase.BusinessRevisionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting business revisions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revisions)
+}
+
+// DiffBusinessRevisions godoc
+// @Router /business/{id}/revisions/diff [get]
+// @Summary Compare two revisions of a business
+// @Description Get the fields that differ between two revisions of a business. Only for its owner and admins.
+// @Security BearerAuth
+// @Tags revision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param from query int true "older revision"
+// @Param to query int true "newer revision"
+// @Success 200 {object} entity.BusinessRevisionDiff
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) DiffBusinessRevisions(ctx *gin.Context) {
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	fromVersion, ok := h.queryVersion(ctx, "from")
+	if !ok {
+		return
+	}
+
+	toVersion, ok := h.queryVersion(ctx, "to")
+	if !ok {
+		return
+	}
+
+	from, err := h.UseCase.BusinessRevisionRepo.GetSingle(ctx, businessID, fromVersion)
+	if h.HandleDbError(ctx, err, "Error getting business revision") {
+		return
+	}
+
+	to, err := h.UseCase.BusinessRevisionRepo.GetSingle(ctx, businessID, toVersion)
+	if h.HandleDbError(ctx, err, "Error getting business revision") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.BusinessRevisionDiff{
+		BusinessID: businessID,
+		From:       from.Version,
+		To:         to.Version,
+		Changes:    diffObjects(from.Snapshot, to.Snapshot),
+	})
+}
+
+// restorableSnapshot drops the categories and attributes of a snapshot that were deleted since it was taken,
+// and the attribute values the catalog no longer accepts, the same way UpdateBusiness and SetBusinessAttributes check them.
+func (h *Handler) restorableSnapshot(ctx *gin.Context, snapshot entity.BusinessSnapshot) (entity.BusinessSnapshot, error) {
+	categoryIDs := []string{}
+	if len(snapshot.CategoryIDs) > 0 {
+		categories, err := h.UseCase.CategoryRepo.GetList(ctx, entity.GetListFilter{
+			Limit:   len(snapshot.CategoryIDs),
+			Filters: []entity.Filter{{Column: "id", Type: "in", Value: strings.Join(snapshot.CategoryIDs, ",")}},
+		})
+		if err != nil {
+			return snapshot, err
+		}
+
+		exists := make(map[string]bool, len(categories.Items))
+		for _, category := range categories.Items {
+			exists[category.ID] = true
+		}
+		for _, id := range snapshot.CategoryIDs {
+			if exists[id] {
+				categoryIDs = append(categoryIDs, id)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(snapshot.Attributes))
+	for key := range snapshot.Attributes {
+		keys = append(keys, key)
+	}
+
+	attributes, err := h.attributesByKey(ctx, keys)
+	if err != nil {
+		return snapshot, err
+	}
+
+	values := make(map[string]interface{}, len(snapshot.Attributes))
+	for key, value := range snapshot.Attributes {
+		if attribute, ok := attributes[key]; ok && attribute.CheckValue(value) == "" {
+			values[key] = value
+		}
+	}
+
+	snapshot.CategoryIDs = categoryIDs
+	snapshot.Attributes = values
+
+	return snapshot, nil
+}
+
+// RollbackBusiness godoc
+// @Router /business/{id}/rollback [post]
+// @Summary Roll a business back to an earlier revision
+// @Description Restore the content of a business as of an earlier revision. The rollback is recorded as a new revision,
+// @Description so it can be undone the same way. A published business flagged by the content filter goes back to pending.
+// @Description Categories and attributes deleted since the revision, and attribute values no longer valid, are left out.
+// @Security BearerAuth
+// @Tags revision
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param rollback body entity.RollbackBusinessRequest true "Revision to restore"
+// @Success 200 {object} entity.Business
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) RollbackBusiness(ctx *gin.Context) {
+	var body entity.RollbackBusinessRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	revision, err := h.UseCase.BusinessRevisionRepo.GetSingle(ctx, businessID, body.Version)
+	if h.HandleDbError(ctx, err, "Error getting business revision") {
+		return
+	}
+
+	before, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: businessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	snapshot, err := h.restorableSnapshot(ctx, revision.Snapshot)
+	if h.HandleDbError(ctx, err, "Error checking business revision") {
+		return
+	}
+
+	text := businessText(entity.Business{Name: snapshot.Name, Description: snapshot.Description})
+
+	result, err := h.BusinessFilter.Screen(ctx, contentfilter.Content{ID: before.ID, AuthorID: before.CreatedBy, Text: text})
+	if h.HandleDbError(ctx, err, "Error screening business") {
+		return
+	}
+
+	err = h.UseCase.BusinessRepo.Rollback(ctx, businessID, snapshot, contentfilter.Fingerprint(text), GetPrincipal(ctx).UserID)
+	if h.HandleDbError(ctx, err, "Error rolling back business") {
+		return
+	}
+
+	if result.Flagged() {
+		err = h.holdFlaggedContent(ctx, entity.ReportTargetBusiness, before.ID, before.CreatedBy, result)
+		if h.HandleDbError(ctx, err, "Error queueing business for moderation") {
+			return
+		}
+	}
+
+	business, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: businessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, business)
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/contentfilter"
+	"yalp_ulab/pkg/logger"
+)
+
+// fakeBusinessRevisionRepo serves the revisions of testBusinessID by version.
+type fakeBusinessRevisionRepo struct {
+	usecase.BusinessRevisionRepoI
+	revisions map[int]entity.BusinessRevision
+}
+
+func (r *fakeBusinessRevisionRepo) GetSingle(ctx context.Context, businessID string, version int) (entity.BusinessRevision, error) {
+	revision, ok := r.revisions[version]
+	if !ok || businessID != testBusinessID {
+		return entity.BusinessRevision{}, pgx.ErrNoRows
+	}
+	return revision, nil
+}
+
+// fakeRollbackBusinessRepo keeps the snapshots a business is rolled back to.
+type fakeRollbackBusinessRepo struct {
+	*fakeBusinessRepo
+	snapshots []entity.BusinessSnapshot
+}
+
+func (r *fakeRollbackBusinessRepo) Rollback(ctx context.Context, id string, snapshot entity.BusinessSnapshot, textHash, authorID string) error {
+	r.snapshots = append(r.snapshots, snapshot)
+	return nil
+}
+
+// fakeCategoryRepo serves the categories it holds, whatever the filter.
+type fakeCategoryRepo struct {
+	usecase.CategoryRepoI
+	categories []entity.Category
+}
+
+func (r *fakeCategoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CategoryList, error) {
+	return entity.CategoryList{Items: r.categories}, nil
+}
+
+// fakeAttributeRepo serves the attributes it holds, whatever the filter.
+type fakeAttributeRepo struct {
+	usecase.AttributeRepoI
+	attributes []entity.Attribute
+}
+
+func (r *fakeAttributeRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.AttributeList, error) {
+	return entity.AttributeList{Items: r.attributes}, nil
+}
+
+func TestRollbackBusiness_DropsDeletedCategoriesAndAttributes(t *testing.T) {
+	revisions := &fakeBusinessRevisionRepo{revisions: map[int]entity.BusinessRevision{
+		1: {BusinessID: testBusinessID, Version: 1, Snapshot: entity.BusinessSnapshot{
+			Name:        "Cafe Plov",
+			CategoryIDs: []string{"cafe", "deleted-category"},
+			Attributes: map[string]interface{}{
+				"wifi":            "free",
+				"outdoor_seating": true,
+				"deleted":         true,
+				"seats":           "many",
+			},
+		}},
+	}}
+	businesses := &fakeRollbackBusinessRepo{fakeBusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{
+		testBusinessID: {ID: testBusinessID, CreatedBy: testUserID},
+	}}}
+
+	h := &Handler{
+		Logger:         logger.New("error"),
+		Config:         &config.Config{},
+		BusinessFilter: contentfilter.New(),
+		UseCase: &usecase.UseCase{
+			BusinessRepo:         businesses,
+			BusinessRevisionRepo: revisions,
+			CategoryRepo:         &fakeCategoryRepo{categories: []entity.Category{{ID: "cafe"}}},
+			AttributeRepo: &fakeAttributeRepo{attributes: []entity.Attribute{
+				{Key: "wifi", Type: entity.AttributeTypeEnum, Options: []string{"free", "paid"}},
+				{Key: "outdoor_seating", Type: entity.AttributeTypeBool},
+				{Key: "seats", Type: entity.AttributeTypeNumber},
+			}},
+		},
+	}
+
+	ctx, w := newPrincipalContext(entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, http.MethodPost, `{"version": 1}`)
+	ctx.Params = gin.Params{{Key: "id", Value: testBusinessID}}
+	h.RollbackBusiness(ctx)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(businesses.snapshots) != 1 {
+		t.Fatalf("expected one rollback, got %d", len(businesses.snapshots))
+	}
+
+	snapshot := businesses.snapshots[0]
+	if len(snapshot.CategoryIDs) != 1 || snapshot.CategoryIDs[0] != "cafe" {
+		t.Fatalf("category_ids = %v, want [cafe]", snapshot.CategoryIDs)
+	}
+	if len(snapshot.Attributes) != 2 || snapshot.Attributes["wifi"] != "free" || snapshot.Attributes["outdoor_seating"] != true {
+		t.Fatalf("attributes = %v, want wifi and outdoor_seating", snapshot.Attributes)
+	}
+	if _, ok := revisions.revisions[1].Snapshot.Attributes["deleted"]; !ok {
+		t.Fatal("the stored revision was changed")
+	}
+}
+
+func TestDiffBusinessRevisions(t *testing.T) {
+	snapshot := entity.BusinessSnapshot{
+		Name:        "Cafe Plov",
+		Description: "Plov and tea",
+		PriceLevel:  1,
+		Attachments: []string{},
+	}
+	renamed := snapshot
+	renamed.Name = "Plov House"
+	renamed.PriceLevel = 2
+
+	revisions := &fakeBusinessRevisionRepo{revisions: map[int]entity.BusinessRevision{
+		1: {BusinessID: testBusinessID, Version: 1, Snapshot: snapshot},
+		2: {BusinessID: testBusinessID, Version: 2, Snapshot: renamed},
+	}}
+
+	tests := []struct {
+		name      string
+		principal entity.Principal
+		query     string
+		status    int
+		changes   []string
+	}{
+		{"owner", entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, "from=1&to=2", http.StatusOK,
+			[]string{"name", "price_level"}},
+		{"same revision", entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, "from=2&to=2", http.StatusOK, nil},
+		{"admin", entity.Principal{UserID: testAdminID, UserRole: entity.UserRoleAdmin}, "from=1&to=2", http.StatusOK,
+			[]string{"name", "price_level"}},
+		{"another user", entity.Principal{UserID: spoofedUserID, UserRole: entity.UserRoleUser}, "from=1&to=2", http.StatusForbidden, nil},
+		{"not a revision number", entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, "from=0&to=2", http.StatusBadRequest, nil},
+		{"missing revision", entity.Principal{UserID: testUserID, UserRole: entity.UserRoleUser}, "from=1&to=3", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				UseCase: &usecase.UseCase{
+					BusinessRepo: &fakeBusinessRepo{businesses: map[string]entity.Business{testBusinessID: {
+						ID:        testBusinessID,
+						CreatedBy: testUserID,
+					}}},
+					BusinessRevisionRepo: revisions,
+				},
+			}
+
+			ctx, w := newPrincipalContext(tt.principal, http.MethodGet, "")
+			ctx.Request.URL.RawQuery = tt.query
+			ctx.Params = gin.Params{{Key: "id", Value: testBusinessID}}
+			h.DiffBusinessRevisions(ctx)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var diff entity.BusinessRevisionDiff
+			if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil {
+				t.Fatalf("decoding diff: %v", err)
+			}
+			if len(diff.Changes) != len(tt.changes) {
+				t.Fatalf("changes = %v, want %v", diff.Changes, tt.changes)
+			}
+			for _, field := range tt.changes {
+				if _, ok := diff.Changes[field]; !ok {
+					t.Fatalf("changes = %v, want %v", diff.Changes, tt.changes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/contentfilter"
+)
+
+// flagReasons maps content filters to the report reason of the moderation case they open; the rest are spam.
+var flagReasons = map[string]string{
+	contentfilter.FilterWords: entity.ReportReasonOffensive,
+}
+
+// newReviewFilter screens reviews: blocked words, links and phone numbers, text copied from other users
+// and the daily review limit.
+func newReviewFilter(c *config.Config, useCase *usecase.UseCase) *contentfilter.Pipeline {
+	return contentfilter.New(
+		contentfilter.NewWordFilter(contentfilter.Profanity, c.ContentFilter.Blocklist),
+		contentfilter.LinkFilter{},
+		contentfilter.PhoneFilter{},
+		&contentfilter.DuplicateFilter{
+			CountCopies: func(ctx context.Context, content contentfilter.Content) (int, error) {
+				reviews, err := useCase.ReviewRepo.GetList(ctx, entity.GetListFilter{
+					Limit: 1,
+					Filters: []entity.Filter{
+						{Column: "r.text_hash", Type: "eq", Value: contentfilter.Fingerprint(content.Text)},
+						{Column: "r.user_id", Type: "neq", Value: content.AuthorID},
+					},
+				})
+				return reviews.Count, err
+			},
+		},
+		&contentfilter.RateLimitFilter{
+			Limit: c.ContentFilter.ReviewsPerDay,
+			CountRecent: func(ctx context.Context, content contentfilter.Content) (int, error) {
+				reviews, err := useCase.ReviewRepo.GetList(ctx, entity.GetListFilter{
+					Limit: 1,
+					Filters: []entity.Filter{
+						{Column: "r.user_id", Type: "eq", Value: content.AuthorID},
+						{Column: "r.created_at", Type: "gte", Value: time.Now().Add(-24 * time.Hour).Format(time.RFC3339)},
+					},
+				})
+				return reviews.Count, err
+			},
+		},
+	)
+}
+
+// newBusinessFilter screens business names and descriptions. Links and phone numbers are expected there.
+func newBusinessFilter(c *config.Config, useCase *usecase.UseCase) *contentfilter.Pipeline {
+	return contentfilter.New(
+		contentfilter.NewWordFilter(contentfilter.Profanity, c.ContentFilter.Blocklist),
+		&contentfilter.DuplicateFilter{
+			CountCopies: func(ctx context.Context, content contentfilter.Content) (int, error) {
+				businesses, err := useCase.BusinessRepo.GetList(ctx, entity.GetListFilter{
+					Limit: 1,
+					Filters: []entity.Filter{
+						{Column: "text_hash", Type: "eq", Value: contentfilter.Fingerprint(content.Text)},
+						{Column: "created_by", Type: "neq", Value: content.AuthorID},
+					},
+				})
+				return businesses.Count, err
+			},
+		},
+	)
+}
+
+// businessText is the part of a business the content filter screens.
+func businessText(business entity.Business) string {
+	return business.Name + "\n" + business.Description
+}
+
+// queueFlaggedContent puts content the filter held in the moderation queue, with the flags in the history.
+func (h *Handler) queueFlaggedContent(ctx *gin.Context, targetType, targetID, ownerID string, result contentfilter.Result) error {
+	reason, ok := flagReasons[result.Flags[0].Filter]
+	if !ok {
+		reason = entity.ReportReasonSpam
+	}
+
+	item, err := h.UseCase.ModerationCaseRepo.Open(ctx, entity.ModerationCase{
+		TargetType:    targetType,
+		TargetID:      targetID,
+		TargetOwnerID: ownerID,
+		ReasonCode:    reason,
+		Note:          result.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = h.UseCase.ModerationHistoryRepo.Create(ctx, entity.ModerationHistory{
+		CaseID:     item.ID,
+		Action:     entity.ModerationActionFlag,
+		ReasonCode: reason,
+		Note:       result.String(),
+	})
+
+	return err
+}
+
+// holdFlaggedContent moves edited content back to pending when the filter flags it. Hidden content stays hidden.
+func (h *Handler) holdFlaggedContent(ctx *gin.Context, targetType, targetID, ownerID string, result contentfilter.Result) error {
+	err := h.setContentStatus(ctx, targetType, targetID, entity.ContentStatusPublished, entity.ContentStatusPending)
+	if err != nil {
+		return err
+	}
+
+	return h.queueFlaggedContent(ctx, targetType, targetID, ownerID, result)
+}
@@ -2,9 +2,9 @@ package handler
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"yalp_ulab/config"
 	"yalp_ulab/internal/entity"
 )
 
@@ -26,6 +26,10 @@ func (h *Handler) GetSession(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
+	if !h.AuthorizeResource(ctx, entity.ResourceSession, req.ID) {
+		return
+	}
+
 	session, err := h.UseCase.SessionRepo.GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting session") {
 		return
@@ -56,19 +60,22 @@ func (h *Handler) GetSessions(ctx *gin.Context) {
 	limit := ctx.DefaultQuery("limit", "10")
 	userId := ctx.DefaultQuery("user_id", "")
 
-	if ctx.GetHeader("user_type") == "user" {
-		userId = ctx.GetHeader("sub")
+	if !GetPrincipal(ctx).IsAdmin() {
+		userId = GetPrincipal(ctx).UserID
 	}
 
 	req.Page, _ = strconv.Atoi(page)
 	req.Limit, _ = strconv.Atoi(limit)
-	req.Filters = append(req.Filters,
-		entity.Filter{
-			Column: "user_id",
-			Type:   "eq",
-			Value:  userId,
-		},
-	)
+
+	if userId != "" {
+		req.Filters = append(req.Filters,
+			entity.Filter{
+				Column: "user_id",
+				Type:   "eq",
+				Value:  userId,
+			},
+		)
+	}
 
 	req.OrderBy = append(req.OrderBy, entity.OrderBy{
 		Column: "created_at",
@@ -80,9 +87,76 @@ func (h *Handler) GetSessions(ctx *gin.Context) {
 		return
 	}
 
+	for i := range sessions.Items {
+		sessions.Items[i].IsCurrent = sessions.Items[i].ID == GetPrincipal(ctx).SessionID
+	}
+
 	ctx.JSON(200, sessions)
 }
 
+// GetCurrentSession godoc
+// @Router /session/me [get]
+// @Summary Get the current session
+// @Description Get the session the request is authenticated with
+// @Security BearerAuth
+// @Tags session
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} entity.Session
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) GetCurrentSession(ctx *gin.Context) {
+	session, err := h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: GetPrincipal(ctx).SessionID})
+	if h.HandleDbError(ctx, err, "Error getting session") {
+		return
+	}
+
+	session.IsCurrent = true
+
+	ctx.JSON(200, session)
+}
+
+// RevokeOtherSessions godoc
+// @Router /session/revoke-others [post]
+// @Summary Revoke all other sessions
+// @Description Deactivate every active session of the current user except the current one
+// @Security BearerAuth
+// @Tags session
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} entity.RowsEffected
+// @Failure 400 {object} entity.ErrorResponse
+func (h *Handler) RevokeOtherSessions(ctx *gin.Context) {
+	var (
+		userID    = GetPrincipal(ctx).UserID
+		sessionID = GetPrincipal(ctx).SessionID
+	)
+
+	rows, err := h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{
+			{Column: "user_id", Type: "eq", Value: userID},
+			{Column: "id", Type: "neq", Value: sessionID},
+			{Column: "is_active", Type: "eq", Value: "true"},
+		},
+		Items: []entity.UpdateFieldItem{
+			{Column: "is_active", Value: "false"},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if h.HandleDbError(ctx, err, "Error revoking sessions") {
+		return
+	}
+
+	h.invalidateUserSessions(ctx, userID)
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionSessionRevoke,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   userID,
+	}, nil, rows)
+
+	ctx.JSON(200, rows)
+}
+
 // UpdateSession godoc
 // @Router /session [put]
 // @Summary Update a session
@@ -99,9 +173,16 @@ func (h *Handler) UpdateSession(ctx *gin.Context) {
 		body entity.Session
 	)
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", 400)
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceSession, body.ID) {
+		return
+	}
+
+	before, err := h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting session") {
 		return
 	}
 
@@ -110,6 +191,17 @@ func (h *Handler) UpdateSession(ctx *gin.Context) {
 		return
 	}
 
+	h.invalidateSession(ctx, before.UserID, body.ID)
+
+	after, err := h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: body.ID})
+	if err == nil {
+		h.RecordAudit(ctx, entity.AuditLog{
+			Action:     entity.AuditActionSessionUpdate,
+			TargetType: entity.AuditTargetSession,
+			TargetID:   body.ID,
+		}, before, after)
+	}
+
 	ctx.JSON(200, session)
 }
 
@@ -131,11 +223,28 @@ func (h *Handler) DeleteSession(ctx *gin.Context) {
 
 	req.ID = ctx.Param("id")
 
-	err := h.UseCase.SessionRepo.Delete(ctx, req)
+	if !h.AuthorizeResource(ctx, entity.ResourceSession, req.ID) {
+		return
+	}
+
+	before, err := h.UseCase.SessionRepo.GetSingle(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting session") {
+		return
+	}
+
+	err = h.UseCase.SessionRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting session") {
 		return
 	}
 
+	h.invalidateSession(ctx, before.UserID, req.ID)
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionSessionDelete,
+		TargetType: entity.AuditTargetSession,
+		TargetID:   req.ID,
+	}, before, nil)
+
 	ctx.JSON(200, entity.SuccessResponse{
 		Message: "Session deleted successfully",
 	})
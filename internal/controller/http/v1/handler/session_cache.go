@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+// cachedSession is what AuthMiddleware keeps in Redis to validate a request without hitting Postgres.
+type cachedSession struct {
+	Session    entity.Session `json:"session"`
+	UserStatus string         `json:"user_status"`
+}
+
+func sessionCacheKey(userID, sessionID string) string {
+	return fmt.Sprintf("session-%s-%s", userID, sessionID)
+}
+
+// getCachedSession returns the session and its owner's status from Redis, falling back to Postgres on a miss.
+func (h *Handler) getCachedSession(ctx *gin.Context, userID, sessionID string) (cachedSession, error) {
+	var item cachedSession
+
+	key := sessionCacheKey(userID, sessionID)
+
+	value, err := h.Redis.Get(ctx, key)
+	if err == nil && json.Unmarshal([]byte(value), &item) == nil {
+		return item, nil
+	}
+
+	item.Session, err = h.UseCase.SessionRepo.GetSingle(ctx, entity.Id{ID: sessionID})
+	if err != nil {
+		return item, err
+	}
+
+	if item.Session.UserID != userID {
+		return item, fmt.Errorf("session %s does not belong to user %s", sessionID, userID)
+	}
+
+	user, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: item.Session.UserID})
+	if err != nil {
+		return item, err
+	}
+
+	item.UserStatus = user.Status
+
+	h.setCachedSession(ctx, item)
+
+	return item, nil
+}
+
+func (h *Handler) setCachedSession(ctx *gin.Context, item cachedSession) {
+	body, err := json.Marshal(item)
+	if err != nil {
+		h.Logger.Error(err, "Error marshalling cached session")
+		return
+	}
+
+	key := sessionCacheKey(item.Session.UserID, item.Session.ID)
+
+	err = h.Redis.Set(ctx, key, string(body), int(config.SessionCacheTTL.Seconds()))
+	if err != nil {
+		h.Logger.Error(err, "Error caching session")
+	}
+}
+
+// invalidateSession drops a single session from the cache.
+func (h *Handler) invalidateSession(ctx *gin.Context, userID, sessionID string) {
+	err := h.Redis.Del(ctx, sessionCacheKey(userID, sessionID))
+	if err != nil {
+		h.Logger.Error(err, "Error invalidating cached session")
+	}
+}
+
+// invalidateUserSessions drops every cached session of a user, e.g. after a status or role change.
+func (h *Handler) invalidateUserSessions(ctx *gin.Context, userID string) {
+	err := h.Redis.DelWildCard(ctx, sessionCacheKey(userID, "*"))
+	if err != nil {
+		h.Logger.Error(err, "Error invalidating cached sessions")
+	}
+}
+
+// deactivateSession marks an expired or idle session inactive in Postgres and drops it from the cache.
+func (h *Handler) deactivateSession(ctx *gin.Context, session entity.Session) {
+	_, err := h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: session.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "is_active", Value: "false"},
+			{Column: "updated_at", Value: time.Now().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		h.Logger.Error(err, "Error deactivating session")
+	}
+
+	h.invalidateSession(ctx, session.UserID, session.ID)
+}
+
+// touchSession updates last_active_at at most once per config.SessionLastActiveEvery.
+func (h *Handler) touchSession(ctx *gin.Context, item cachedSession, now time.Time) {
+	lastActiveAt, err := time.Parse(time.RFC3339, item.Session.LastActiveAt)
+	if err == nil && now.Sub(lastActiveAt) < config.SessionLastActiveEvery {
+		return
+	}
+
+	_, err = h.UseCase.SessionRepo.UpdateField(ctx, entity.UpdateFieldRequest{
+		Filter: []entity.Filter{{Column: "id", Type: "eq", Value: item.Session.ID}},
+		Items: []entity.UpdateFieldItem{
+			{Column: "last_active_at", Value: now.Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		h.Logger.Error(err, "Error updating session last_active_at")
+		return
+	}
+
+	item.Session.LastActiveAt = now.Format(time.RFC3339)
+	h.setCachedSession(ctx, item)
+}
+
+// validateSession checks that a session is active, not expired, not idle and owned by a user who isn't blocked.
+// It returns the HTTP status and error message to abort with when the session is not valid.
+func (h *Handler) validateSession(ctx *gin.Context, userID, sessionID string) (int, string) {
+	item, err := h.getCachedSession(ctx, userID, sessionID)
+	if err != nil {
+		h.Logger.Error(err, "Error getting session")
+		return http.StatusUnauthorized, "Session is invalid"
+	}
+
+	if !item.Session.IsActive {
+		return http.StatusUnauthorized, "Session is not active"
+	}
+
+	now := time.Now()
+
+	expiresAt, err := time.Parse(time.RFC3339, item.Session.ExpiresAt)
+	if err == nil && now.After(expiresAt) {
+		h.deactivateSession(ctx, item.Session)
+		return http.StatusUnauthorized, "Session is expired"
+	}
+
+	lastActiveAt, err := time.Parse(time.RFC3339, item.Session.LastActiveAt)
+	if err == nil && now.Sub(lastActiveAt) > config.SessionIdleTimeout {
+		h.deactivateSession(ctx, item.Session)
+		return http.StatusUnauthorized, "Session is expired"
+	}
+
+	if item.UserStatus == entity.UserStatusBlocked {
+		return http.StatusForbidden, "User is blocked"
+	}
+
+	h.touchSession(ctx, item, now)
+
+	return 0, ""
+}
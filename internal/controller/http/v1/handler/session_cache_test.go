@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/logger"
+)
+
+func TestValidateSession(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		session     func(s *entity.Session)
+		userStatus  string
+		status      int
+		deactivated bool
+	}{
+		{name: "valid", status: 0},
+		{name: "inactive", session: func(s *entity.Session) { s.IsActive = false }, status: http.StatusUnauthorized},
+		{name: "owned by someone else", session: func(s *entity.Session) { s.UserID = spoofedUserID }, status: http.StatusUnauthorized},
+		{name: "past its absolute expiry", session: func(s *entity.Session) {
+			s.ExpiresAt = now.Add(-time.Minute).Format(time.RFC3339)
+		}, status: http.StatusUnauthorized, deactivated: true},
+		{name: "idle too long", session: func(s *entity.Session) {
+			s.LastActiveAt = now.Add(-config.SessionIdleTimeout - time.Minute).Format(time.RFC3339)
+		}, status: http.StatusUnauthorized, deactivated: true},
+		{name: "idle but not too long", session: func(s *entity.Session) {
+			s.LastActiveAt = now.Add(-config.SessionIdleTimeout + time.Hour).Format(time.RFC3339)
+		}, status: 0},
+		{name: "blocked user", userStatus: entity.UserStatusBlocked, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := entity.Session{
+				ID:           testSessionID,
+				UserID:       testUserID,
+				IsActive:     true,
+				ExpiresAt:    now.Add(time.Hour).Format(time.RFC3339),
+				LastActiveAt: now.Format(time.RFC3339),
+			}
+			if tt.session != nil {
+				tt.session(&session)
+			}
+
+			userStatus := entity.UserStatusActive
+			if tt.userStatus != "" {
+				userStatus = tt.userStatus
+			}
+
+			sessions := &fakeSessionRepo{session: session}
+			redis := &fakeRedis{items: map[string]string{}}
+			h := &Handler{
+				Logger: logger.New("error"),
+				Config: &config.Config{},
+				Redis:  redis,
+				UseCase: &usecase.UseCase{
+					UserRepo:    &fakeUserRepo{user: entity.User{ID: session.UserID, Status: userStatus}},
+					SessionRepo: sessions,
+				},
+			}
+
+			ctx, _ := newPrincipalContext(entity.Principal{}, http.MethodGet, "")
+
+			status, message := h.validateSession(ctx, testUserID, testSessionID)
+			if status != tt.status {
+				t.Fatalf("validateSession() = %d (%q), want %d", status, message, tt.status)
+			}
+
+			deactivated := false
+			for _, update := range sessions.updates {
+				for _, item := range update.Items {
+					if item.Column == "is_active" && item.Value == "false" {
+						deactivated = true
+					}
+				}
+			}
+			if deactivated != tt.deactivated {
+				t.Fatalf("session deactivated = %v, want %v", deactivated, tt.deactivated)
+			}
+			if tt.deactivated {
+				if _, ok := redis.items[sessionCacheKey(testUserID, testSessionID)]; ok {
+					t.Fatal("expected the deactivated session to be dropped from the cache")
+				}
+			}
+		})
+	}
+}
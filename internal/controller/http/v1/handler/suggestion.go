@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/contentfilter"
+)
+
+// getReviewableSuggestion loads a suggestion the current principal may approve or reject,
+// that is one of their business or any one for admins.
+// Like BindJSON, it writes the error response itself and returns false on failure.
+func (h *Handler) getReviewableSuggestion(ctx *gin.Context) (entity.EditSuggestion, bool) {
+	suggestion, err := h.UseCase.EditSuggestionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting edit suggestion") {
+		return suggestion, false
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, suggestion.BusinessID) {
+		return suggestion, false
+	}
+
+	if suggestion.Status != entity.SuggestionStatusPending {
+		h.ReturnError(ctx, config.ErrorConflict, "Edit suggestion was already "+suggestion.Status, http.StatusConflict)
+		return suggestion, false
+	}
+
+	return suggestion, true
+}
+
+// CreateEditSuggestion godoc
+// @Router /business/{id}/suggestions [post]
+// @Summary Suggest a correction to a business
+// @Description Propose new values for some fields of a business, e.g. when it moved or closed permanently.
+// @Description Fields left out of the edit stay as they are. The owner of the business or an admin approves or rejects it.
+// @Security BearerAuth
+// @Tags suggestion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param suggestion body entity.CreateEditSuggestionRequest true "Suggested edit"
+// @Success 201 {object} entity.EditSuggestion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 404 {object} entity.ErrorResponse
+func (h *Handler) CreateEditSuggestion(ctx *gin.Context) {
+	var body entity.CreateEditSuggestionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	business, ok := h.getVisibleBusiness(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	if hours := body.Edit.OpeningHours; hours != nil {
+		if message := hours.Check(); message != "" {
+			h.ReturnValidationError(ctx, entity.FieldError{
+				Field:   "edit.opening_hours.periods",
+				Code:    config.ErrorInvalidValue,
+				Message: message,
+			})
+			return
+		}
+
+		if hours.Periods == nil {
+			hours.Periods = []entity.OpeningPeriod{}
+		}
+	}
+
+	changes := diffObjects(entity.EditOf(business), entity.EditOf(body.Edit.Apply(business)))
+	if len(changes) == 0 {
+		h.ReturnValidationError(ctx, entity.FieldError{
+			Field:   "edit",
+			Code:    config.ErrorInvalidValue,
+			Message: "must change at least one field of the business",
+		})
+		return
+	}
+
+	suggestion, err := h.UseCase.EditSuggestionRepo.Create(ctx, entity.EditSuggestion{
+		BusinessID: business.ID,
+		UserID:     GetPrincipal(ctx).UserID,
+		Edit:       body.Edit,
+		Changes:    changes,
+		Note:       body.Note,
+	})
+	if h.HandleDbError(ctx, err, "Error creating edit suggestion") {
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, suggestion)
+}
+
+// GetEditSuggestions godoc
+// @Router /business/{id}/suggestions [get]
+// @Summary Get the edit suggestions of a business
+// @Description Get the edit suggestions of a business, newest first. Only for its owner and admins.
+// @Security BearerAuth
+// @Tags suggestion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Business ID"
+// @Param page query number true "page"
+// @Param limit query number true "limit"
+// @Param status query string false "pending, approved or rejected"
+// @Success 200 {object} entity.EditSuggestionList
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) GetEditSuggestions(ctx *gin.Context) {
+	var req entity.GetListFilter
+
+	businessID := ctx.Param("id")
+
+	if !h.AuthorizeResource(ctx, entity.ResourceBusiness, businessID) {
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+	limit := ctx.DefaultQuery("limit", "10")
+
+	req.Page, _ = strconv.Atoi(page)
+	req.Limit, _ = strconv.Atoi(limit)
+	req.Filters = append(req.Filters, entity.Filter{
+		Column: "s.business_id",
+		Type:   "eq",
+		Value:  businessID,
+	})
+
+	if status := ctx.Query("status"); status != "" {
+		req.Filters = append(req.Filters, entity.Filter{
+			Column: "s.status",
+			Type:   "eq",
+			Value:  status,
+		})
+	}
+
+	req.OrderBy = append(req.OrderBy, entity.OrderBy{
+		Column: "s.created_at",
+		Order:  "desc",
+	})
+
+	suggestions, err := h.UseCase.EditSuggestionRepo.GetList(ctx, req)
+	if h.HandleDbError(ctx, err, "Error getting edit suggestions") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+// GetEditSuggestion godoc
+// @Router /business/suggestions/{id} [get]
+// @Summary Get an edit suggestion by ID
+// @Description Get an edit suggestion by ID. Only for the user who made it, the owner of the business and admins.
+// @Security BearerAuth
+// @Tags suggestion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Suggestion ID"
+// @Success 200 {object} entity.EditSuggestion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+func (h *Handler) GetEditSuggestion(ctx *gin.Context) {
+	suggestion, err := h.UseCase.EditSuggestionRepo.GetSingle(ctx, entity.Id{ID: ctx.Param("id")})
+	if h.HandleDbError(ctx, err, "Error getting edit suggestion") {
+		return
+	}
+
+	if suggestion.UserID != GetPrincipal(ctx).UserID && !h.AuthorizeResource(ctx, entity.ResourceBusiness, suggestion.BusinessID) {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestion)
+}
+
+// ApproveEditSuggestion godoc
+// @Router /business/suggestions/{id}/approve [post]
+// @Summary Approve an edit suggestion
+// @Description Apply a pending edit suggestion to the business. It is recorded as a revision of the business by the user who made it.
+// @Security BearerAuth
+// @Tags suggestion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Suggestion ID"
+// @Param review body entity.ReviewEditSuggestionRequest true "Note to the user who made the suggestion"
+// @Success 200 {object} entity.EditSuggestion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) ApproveEditSuggestion(ctx *gin.Context) {
+	var body entity.ReviewEditSuggestionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	suggestion, ok := h.getReviewableSuggestion(ctx)
+	if !ok {
+		return
+	}
+
+	before, err := h.UseCase.BusinessRepo.GetSingle(ctx, entity.BusinessSingleRequest{ID: suggestion.BusinessID})
+	if h.HandleDbError(ctx, err, "Error getting business") {
+		return
+	}
+
+	after := suggestion.Edit.Apply(before)
+
+	err = h.UseCase.EditSuggestionRepo.Approve(ctx, suggestion, GetPrincipal(ctx).UserID, body.Note,
+		contentfilter.Fingerprint(businessText(after)))
+	if err == entity.ErrSuggestionReviewed {
+		h.ReturnError(ctx, config.ErrorConflict, "Edit suggestion was already reviewed", http.StatusConflict)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error approving edit suggestion") {
+		return
+	}
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionBusinessEdit,
+		TargetType: entity.AuditTargetBusiness,
+		TargetID:   before.ID,
+	}, entity.EditOf(before), entity.EditOf(after))
+
+	suggestion, err = h.UseCase.EditSuggestionRepo.GetSingle(ctx, entity.Id{ID: suggestion.ID})
+	if h.HandleDbError(ctx, err, "Error getting edit suggestion") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestion)
+}
+
+// RejectEditSuggestion godoc
+// @Router /business/suggestions/{id}/reject [post]
+// @Summary Reject an edit suggestion
+// @Description Reject a pending edit suggestion, the business stays as it is.
+// @Security BearerAuth
+// @Tags suggestion
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Suggestion ID"
+// @Param review body entity.ReviewEditSuggestionRequest true "Note to the user who made the suggestion"
+// @Success 200 {object} entity.EditSuggestion
+// @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
+// @Failure 409 {object} entity.ErrorResponse
+func (h *Handler) RejectEditSuggestion(ctx *gin.Context) {
+	var body entity.ReviewEditSuggestionRequest
+
+	if !h.BindJSON(ctx, &body) {
+		return
+	}
+
+	suggestion, ok := h.getReviewableSuggestion(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.UseCase.EditSuggestionRepo.Reject(ctx, suggestion.ID, GetPrincipal(ctx).UserID, body.Note)
+	if err == entity.ErrSuggestionReviewed {
+		h.ReturnError(ctx, config.ErrorConflict, "Edit suggestion was already reviewed", http.StatusConflict)
+		return
+	}
+	if h.HandleDbError(ctx, err, "Error rejecting edit suggestion") {
+		return
+	}
+
+	suggestion, err = h.UseCase.EditSuggestionRepo.GetSingle(ctx, entity.Id{ID: suggestion.ID})
+	if h.HandleDbError(ctx, err, "Error getting edit suggestion") {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestion)
+}
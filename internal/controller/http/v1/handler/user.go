@@ -18,30 +18,54 @@ import (
 // @Tags user
 // @Accept  json
 // @Produce  json
-// @Param user body entity.User true "User object"
-// @Success 201 {object} entity.User
+// @Param user body entity.CreateUserRequest true "User object"
+// @Success 201 {object} entity.UserResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) CreateUser(ctx *gin.Context) {
-	var body entity.User
+	var body entity.CreateUserRequest
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
 		return
 	}
 
-	body.Password, err = hash.HashPassword(body.Password)
+	if body.UserRole == "" {
+		body.UserRole = entity.UserRoleUser
+	}
+
+	if body.Status == "" {
+		body.Status = entity.UserStatusActive
+	}
+
+	if !h.canGrantRole(ctx, body.UserRole) {
+		h.ReturnError(ctx, config.ErrorForbidden, "Not allowed to grant this role", http.StatusForbidden)
+		return
+	}
+
+	password, err := hash.HashPassword(body.Password)
 	if err != nil {
 		h.ReturnError(ctx, config.ErrorInternalServer, "Error hashing password", http.StatusInternalServerError)
 		return
 	}
 
-	user, err := h.UseCase.UserRepo.Create(ctx, body)
+	user, err := h.UseCase.UserRepo.Create(ctx, entity.User{
+		Email:    body.Email,
+		Password: password,
+		FullName: body.FullName,
+		UserType: userTypeByRole[body.UserRole],
+		UserRole: body.UserRole,
+		Status:   body.Status,
+	})
 	if h.HandleDbError(ctx, err, "Error creating user") {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, user)
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionUserCreate,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   user.ID,
+	}, nil, user)
+
+	ctx.JSON(http.StatusCreated, user.ToResponse())
 }
 
 // GetUser godoc
@@ -53,21 +77,23 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param id path string true "User ID"
-// @Success 200 {object} entity.User
+// @Success 200 {object} entity.UserResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetUser(ctx *gin.Context) {
 	var req entity.UserSingleRequest
 
 	req.ID = ctx.Param("id")
 
+	if !h.AuthorizeResource(ctx, entity.ResourceUser, req.ID) {
+		return
+	}
+
 	user, err := h.UseCase.UserRepo.GetSingle(ctx, req)
 	if h.HandleDbError(ctx, err, "Error getting user") {
 		return
 	}
 
-	user.Password = ""
-
-	ctx.JSON(http.StatusOK, user)
+	ctx.JSON(http.StatusOK, user.ToResponse())
 }
 
 // GetUsers godoc
@@ -81,7 +107,7 @@ func (h *Handler) GetUser(ctx *gin.Context) {
 // @Param page query number true "page"
 // @Param limit query number true "limit"
 // @Param search query string false "search"
-// @Success 200 {object} entity.UserList
+// @Success 200 {object} entity.UserListResponse
 // @Failure 400 {object} entity.ErrorResponse
 func (h *Handler) GetUsers(ctx *gin.Context) {
 	var req entity.GetListFilter
@@ -120,7 +146,7 @@ func (h *Handler) GetUsers(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, users)
+	ctx.JSON(http.StatusOK, users.ToResponse())
 }
 
 // UpdateUser godoc
@@ -131,36 +157,119 @@ func (h *Handler) GetUsers(ctx *gin.Context) {
 // @Tags user
 // @Accept  json
 // @Produce  json
-// @Param user body entity.User true "User object"
-// @Success 200 {object} entity.User
+// @Param user body entity.UpdateUserRequest true "User object"
+// @Success 200 {object} entity.UserResponse
 // @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
 func (h *Handler) UpdateUser(ctx *gin.Context) {
-	var body entity.User
+	var body entity.UpdateUserRequest
 
-	err := ctx.ShouldBindJSON(&body)
-	if err != nil {
-		h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	if !h.BindJSON(ctx, &body) {
 		return
 	}
 
-	if ctx.GetHeader("user_type") == "user" {
-		body.ID = ctx.GetHeader("sub")
+	if body.ID == "" {
+		body.ID = GetPrincipal(ctx).UserID
+	}
+
+	if !h.AuthorizeResource(ctx, entity.ResourceUser, body.ID) {
+		return
+	}
+
+	before, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	principal := GetPrincipal(ctx)
+
+	// Whoever can change another user's email or password can log in as them,
+	// so editing anyone but yourself takes the same rights as blocking them.
+	if before.ID != principal.UserID {
+		if ok, message := h.canManageUser(ctx, before); !ok {
+			h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+			return
+		}
+	}
+
+	roleChanged := body.UserRole != "" && body.UserRole != before.UserRole
+	statusChanged := body.Status != "" && body.Status != before.Status
+	passwordReset := body.Password != "" && before.ID != principal.UserID
+
+	if roleChanged || statusChanged {
+		if !principal.IsAdmin() {
+			h.ReturnError(ctx, config.ErrorForbidden, "Only admins can change user role or status", http.StatusForbidden)
+			return
+		}
+
+		if ok, message := h.canManageUser(ctx, before); !ok {
+			h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+			return
+		}
+
+		if roleChanged && !h.canGrantRole(ctx, body.UserRole) {
+			h.ReturnError(ctx, config.ErrorForbidden, "Not allowed to grant this role", http.StatusForbidden)
+			return
+		}
+	}
+
+	// Start from the stored user so that omitted fields keep their value
+	user := before
+	user.Password = ""
+
+	if body.Email != "" {
+		user.Email = body.Email
+	}
+
+	if body.FullName != "" {
+		user.FullName = body.FullName
+	}
+
+	if roleChanged {
+		user.UserRole = body.UserRole
+		user.UserType = userTypeByRole[body.UserRole]
+	}
+
+	if statusChanged {
+		user.Status = body.Status
 	}
 
 	if body.Password != "" {
-		body.Password, err = hash.HashPassword(body.Password)
+		user.Password, err = hash.HashPassword(body.Password)
 		if err != nil {
 			h.ReturnError(ctx, config.ErrorInternalServer, "Error hashing password", http.StatusInternalServerError)
 			return
 		}
 	}
 
-	user, err := h.UseCase.UserRepo.Update(ctx, body)
+	_, err = h.UseCase.UserRepo.Update(ctx, user)
 	if h.HandleDbError(ctx, err, "Error updating user") {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	h.invalidateUserSessions(ctx, body.ID)
+
+	// Existing tokens carry the old role, and a blocked user or one whose password an admin reset
+	// must not keep any session.
+	if roleChanged || passwordReset || (statusChanged && user.Status == entity.UserStatusBlocked) {
+		err = h.revokeUserSessions(ctx, body.ID)
+		if h.HandleDbError(ctx, err, "Error revoking user sessions") {
+			return
+		}
+	}
+
+	after, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: body.ID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionUserUpdate,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   body.ID,
+	}, before, after)
+
+	ctx.JSON(http.StatusOK, after.ToResponse())
 }
 
 // DeleteUser godoc
@@ -174,20 +283,41 @@ func (h *Handler) UpdateUser(ctx *gin.Context) {
 // @Param id path string true "User ID"
 // @Success 200 {object} entity.SuccessResponse
 // @Failure 400 {object} entity.ErrorResponse
+// @Failure 403 {object} entity.ErrorResponse
 func (h *Handler) DeleteUser(ctx *gin.Context) {
 	var req entity.Id
 
 	req.ID = ctx.Param("id")
 
-	if ctx.GetHeader("user_type") == "user" {
-		req.ID = ctx.GetHeader("sub")
+	if !h.AuthorizeResource(ctx, entity.ResourceUser, req.ID) {
+		return
 	}
 
-	err := h.UseCase.UserRepo.Delete(ctx, req)
+	before, err := h.UseCase.UserRepo.GetSingle(ctx, entity.UserSingleRequest{ID: req.ID})
+	if h.HandleDbError(ctx, err, "Error getting user") {
+		return
+	}
+
+	if before.ID != GetPrincipal(ctx).UserID {
+		if ok, message := h.canManageUser(ctx, before); !ok {
+			h.ReturnError(ctx, config.ErrorForbidden, message, http.StatusForbidden)
+			return
+		}
+	}
+
+	err = h.UseCase.UserRepo.Delete(ctx, req)
 	if h.HandleDbError(ctx, err, "Error deleting user") {
 		return
 	}
 
+	h.invalidateUserSessions(ctx, req.ID)
+
+	h.RecordAudit(ctx, entity.AuditLog{
+		Action:     entity.AuditActionUserDelete,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   req.ID,
+	}, before, nil)
+
 	ctx.JSON(http.StatusOK, entity.SuccessResponse{
 		Message: "User deleted successfully",
 	})
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+)
+
+const passwordMinLength = 8
+
+// slugPattern is what machine-readable keys look like, e.g. "outdoor_seating"
+var slugPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// clockPattern is a time of day, "HH:MM" from "00:00" to "24:00"
+var clockPattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$|^24:00$`)
+
+// enumValidators are the custom `binding` tags that restrict a field to a fixed set of values.
+var enumValidators = map[string][]string{
+	"platform":    {entity.PlatformAdmin, entity.PlatformWeb, entity.PlatformMobile},
+	"user_role":   {entity.UserRoleUser, entity.UserRoleAdmin, entity.UserRoleSuperAdmin},
+	"user_status": {entity.UserStatusActive, entity.UserStatusBlocked, entity.UserStatusInVerify},
+	"review_vote": {entity.ReviewVoteUseful, entity.ReviewVoteFunny, entity.ReviewVoteCool},
+	"menu_kind":   {entity.MenuKindMenu, entity.MenuKindServices},
+	"promotion_kind": {
+		entity.PromotionKindDeal,
+		entity.PromotionKindEvent,
+	},
+	"attribute_type": {
+		entity.AttributeTypeBool,
+		entity.AttributeTypeEnum,
+		entity.AttributeTypeNumber,
+	},
+	"dietary_tag": {
+		entity.DietaryVegetarian,
+		entity.DietaryVegan,
+		entity.DietaryGlutenFree,
+		entity.DietaryDairyFree,
+		entity.DietaryNutFree,
+		entity.DietaryHalal,
+		entity.DietaryKosher,
+		entity.DietarySpicy,
+	},
+	"profile_visibility": {
+		entity.ProfileVisibilityPublic,
+		entity.ProfileVisibilityFollowers,
+		entity.ProfileVisibilityPrivate,
+	},
+	"report_target": {
+		entity.ReportTargetBusiness,
+		entity.ReportTargetReview,
+		entity.ReportTargetReviewReply,
+		entity.ReportTargetPhoto,
+	},
+	"moderation_action": {
+		entity.ModerationActionHide,
+		entity.ModerationActionDelete,
+		entity.ModerationActionWarnUser,
+		entity.ModerationActionBlockUser,
+	},
+	"report_reason": {
+		entity.ReportReasonSpam,
+		entity.ReportReasonOffensive,
+		entity.ReportReasonHarassment,
+		entity.ReportReasonFalseInfo,
+		entity.ReportReasonConflict,
+		entity.ReportReasonOther,
+	},
+}
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	// Report fields by their JSON name, that is what clients send
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	for tag, values := range enumValidators {
+		values := values
+		_ = v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return contains(values, fl.Field().String())
+		})
+	}
+
+	_ = v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return isStrongPassword(fl.Field().String())
+	})
+
+	_ = v.RegisterValidation("slug", func(fl validator.FieldLevel) bool {
+		return slugPattern.MatchString(fl.Field().String())
+	})
+
+	_ = v.RegisterValidation("clock", func(fl validator.FieldLevel) bool {
+		return clockPattern.MatchString(fl.Field().String())
+	})
+}
+
+// isStrongPassword requires at least passwordMinLength characters with a letter and a digit.
+func isStrongPassword(password string) bool {
+	var hasLetter, hasDigit bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+
+	return len([]rune(password)) >= passwordMinLength && hasLetter && hasDigit
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// BindJSON binds and validates the request body. It writes the error response itself and returns false on failure.
+func (h *Handler) BindJSON(ctx *gin.Context, body interface{}) bool {
+	err := ctx.ShouldBindJSON(body)
+	if err == nil {
+		return true
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fields := make([]entity.FieldError, 0, len(validationErrors))
+		for _, e := range validationErrors {
+			fields = append(fields, fieldError(e))
+		}
+
+		h.ReturnValidationError(ctx, fields...)
+		return false
+	}
+
+	h.ReturnError(ctx, config.ErrorBadRequest, "Invalid request body", http.StatusBadRequest)
+	return false
+}
+
+// ReturnValidationError responds with 400 and the per-field errors.
+func (h *Handler) ReturnValidationError(ctx *gin.Context, fields ...entity.FieldError) {
+	h.Logger.Error(fmt.Errorf("validation failed: %v", fields))
+	ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+		Message: "Validation failed",
+		Code:    config.ErrorInvalidRequest,
+		Errors:  fields,
+	})
+}
+
+// fieldError turns a validator error into a FieldError with a code clients can switch on.
+func fieldError(e validator.FieldError) entity.FieldError {
+	field := entity.FieldError{
+		Field: fieldPath(e),
+		Code:  config.ErrorInvalidValue,
+	}
+
+	switch e.Tag() {
+	case "required", "required_if":
+		field.Code = config.ErrorRequiredField
+		field.Message = "is required"
+	case "email":
+		field.Code = config.ErrorInvalidEmail
+		field.Message = "must be a valid email address"
+	case "password":
+		field.Code = config.ErrorInvalidPass
+		field.Message = fmt.Sprintf("must be at least %d characters long and contain a letter and a digit", passwordMinLength)
+	case "uuid":
+		field.Message = "must be a valid UUID"
+	case "numeric":
+		field.Message = "must contain digits only"
+	case "len":
+		field.Message = fmt.Sprintf("must be exactly %s characters long", e.Param())
+	case "min":
+		field.Message = fmt.Sprintf("must be at least %s%s", e.Param(), lengthUnit(e))
+	case "max":
+		field.Message = fmt.Sprintf("must be at most %s%s", e.Param(), lengthUnit(e))
+	case "oneof":
+		field.Message = "must be one of: " + strings.Join(strings.Fields(e.Param()), ", ")
+	case "startswith":
+		field.Message = fmt.Sprintf("must start with %q", e.Param())
+	case "slug":
+		field.Message = "must be lowercase letters, digits and underscores, starting with a letter"
+	case "iso4217":
+		field.Message = "must be an ISO 4217 currency code"
+	case "clock":
+		field.Message = "must be a time of day from 00:00 to 24:00"
+	case "timezone":
+		field.Message = "must be an IANA time zone, e.g. Asia/Tashkent"
+	case "nefield":
+		field.Message = "must differ from " + e.Param()
+	default:
+		if values, ok := enumValidators[e.Tag()]; ok {
+			field.Message = "must be one of: " + strings.Join(values, ", ")
+		} else {
+			field.Message = "is invalid"
+		}
+	}
+
+	return field
+}
+
+// lengthUnit qualifies min/max limits of strings, which are lengths rather than values.
+func lengthUnit(e validator.FieldError) string {
+	if e.Kind() == reflect.String {
+		return " characters long"
+	}
+	return ""
+}
+
+// fieldPath is the JSON path of the field without the top-level struct name, e.g. "location.latitude".
+func fieldPath(e validator.FieldError) string {
+	namespace := e.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+)
+
+func bindJSON(t *testing.T, body string, dest interface{}) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	h := &Handler{Logger: logger.New("error")}
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+
+	return w, h.BindJSON(ctx, dest)
+}
+
+func fieldErrors(t *testing.T, w *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+
+	var response entity.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decoding error response: %v", err)
+	}
+
+	codes := make(map[string]string, len(response.Errors))
+	for _, e := range response.Errors {
+		codes[e.Field] = e.Code
+	}
+
+	return codes
+}
+
+func TestBindJSON_ReportsFieldErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		dest     interface{}
+		expected map[string]string
+	}{
+		{
+			name: "register",
+			body: `{"full_name": "", "email": "not-an-email", "password": "short"}`,
+			dest: &entity.RegisterRequest{},
+			expected: map[string]string{
+				"full_name": config.ErrorRequiredField,
+				"email":     config.ErrorInvalidEmail,
+				"password":  config.ErrorInvalidPass,
+			},
+		},
+		{
+			name: "login with unknown platform",
+			body: `{"email": "user@example.com", "password": "secret", "platform": "desktop"}`,
+			dest: &entity.LoginRequest{},
+			expected: map[string]string{
+				"platform": config.ErrorInvalidValue,
+			},
+		},
+		{
+			name: "verify email",
+			body: `{"email": "user@example.com", "otp": "12ab", "platform": "web"}`,
+			dest: &entity.VerifyEmail{},
+			expected: map[string]string{
+				"otp": config.ErrorInvalidValue,
+			},
+		},
+		{
+			name: "business",
+			body: `{"name": "Cafe", "categories": ["Bakery"], "location": {"latitude": 91, "longitude": 0}}`,
+			dest: &entity.Business{},
+			expected: map[string]string{
+				"categories[0]":     config.ErrorInvalidValue,
+				"location.latitude": config.ErrorInvalidValue,
+			},
+		},
+		{
+			name: "menu",
+			body: `{"kind": "drinks", "name": "Dinner", "currency": "XYZ",
+				"sections": [{"name": "Mains", "items": [{"name": "Soup", "price": -1, "dietary_tags": ["vegan", "paleo"]}]}]}`,
+			dest: &entity.CreateMenuRequest{},
+			expected: map[string]string{
+				"kind":                                 config.ErrorInvalidValue,
+				"currency":                             config.ErrorInvalidValue,
+				"sections[0].items[0].price":           config.ErrorInvalidValue,
+				"sections[0].items[0].dietary_tags[1]": config.ErrorInvalidValue,
+			},
+		},
+		{
+			name: "opening hours",
+			body: `{"timezone": "Mars/Olympus", "periods": [{"day": 7, "open": "9:00", "close": "24:00"}]}`,
+			dest: &entity.OpeningHours{},
+			expected: map[string]string{
+				"timezone":        config.ErrorInvalidValue,
+				"periods[0].day":  config.ErrorInvalidValue,
+				"periods[0].open": config.ErrorInvalidValue,
+			},
+		},
+		{
+			name: "edit suggestion",
+			body: `{"edit": {"location": {"latitude": 91, "longitude": 69.24}, "opening_hours": {"timezone": "UTC", "periods": [{"day": 1, "open": "25:00", "close": "10:00"}]}}}`,
+			dest: &entity.CreateEditSuggestionRequest{},
+			expected: map[string]string{
+				"edit.location.latitude":             config.ErrorInvalidValue,
+				"edit.opening_hours.periods[0].open": config.ErrorInvalidValue,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, ok := bindJSON(t, tt.body, tt.dest)
+			if ok {
+				t.Fatal("expected validation to fail")
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", w.Code)
+			}
+
+			codes := fieldErrors(t, w)
+			if len(codes) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, codes)
+			}
+			for field, code := range tt.expected {
+				if codes[field] != code {
+					t.Fatalf("expected %s for %q, got %v", code, field, codes)
+				}
+			}
+		})
+	}
+}
+
+func TestBindJSON_AcceptsValidBody(t *testing.T) {
+	var body entity.RegisterRequest
+
+	w, ok := bindJSON(t, `{"full_name": "Jane Doe", "email": "jane@example.com", "password": "secret123"}`, &body)
+	if !ok {
+		t.Fatalf("expected validation to pass, got %s", w.Body.String())
+	}
+	if body.Email != "jane@example.com" {
+		t.Fatalf("unexpected body %+v", body)
+	}
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	w, ok := bindJSON(t, `{"email":`, &entity.LoginRequest{})
+	if ok {
+		t.Fatal("expected binding to fail")
+	}
+
+	var response entity.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Code != config.ErrorBadRequest || len(response.Errors) != 0 {
+		t.Fatalf("unexpected response %+v", response)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	_ "yalp_ulab/docs"
 	"yalp_ulab/internal/controller/http/v1/handler"
 	"yalp_ulab/internal/usecase"
+	"yalp_ulab/pkg/geoip"
 	"yalp_ulab/pkg/logger"
 )
 
@@ -29,16 +30,14 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name Authorization
-func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache) {
+func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useCase *usecase.UseCase, redis rediscache.RedisCache, geo *geoip.GeoIP, enforcer *casbin.SyncedEnforcer) {
 	// Options
 	engine.Use(gin.Logger())
 	engine.Use(gin.Recovery())
 
-	handlerV1 := handler.NewHandler(l, config, useCase, redis)
+	handlerV1 := handler.NewHandler(l, config, useCase, redis, geo, enforcer)
 
-	// Initialize Casbin enforcer
-	e := casbin.NewEnforcer("config/rbac.conf", "config/policy.csv")
-	engine.Use(handlerV1.AuthMiddleware(e))
+	engine.Use(handlerV1.AuthMiddleware())
 
 	// Swagger
 	url := ginSwagger.URL("swagger/doc.json") // The url pointing to API definition
@@ -60,11 +59,41 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 		user.GET("/:id", handlerV1.GetUser)
 		user.PUT("/", handlerV1.UpdateUser)
 		user.DELETE("/:id", handlerV1.DeleteUser)
+		user.GET("/:id/checkins", handlerV1.GetUserCheckIns)
+		user.POST("/:id/follow", handlerV1.FollowUser)
+		user.DELETE("/:id/follow", handlerV1.UnfollowUser)
+		user.GET("/:id/followers", handlerV1.GetFollowers)
+		user.GET("/:id/following", handlerV1.GetFollowing)
+
+		collections := user.Group("/collections")
+		{
+			collections.POST("/", handlerV1.CreateCollection)
+			collections.GET("/list", handlerV1.GetCollections)
+			collections.GET("/:id", handlerV1.GetCollection)
+			collections.PUT("/", handlerV1.UpdateCollection)
+			collections.DELETE("/:id", handlerV1.DeleteCollection)
+			collections.POST("/:id/items", handlerV1.AddCollectionItem)
+			collections.GET("/:id/items", handlerV1.GetCollectionItems)
+			collections.DELETE("/:id/items/:business_id", handlerV1.RemoveCollectionItem)
+		}
+	}
+
+	profile := v1.Group("/profile")
+	{
+		profile.GET("/:id", handlerV1.GetProfile)
+		profile.PUT("/", handlerV1.UpdateProfile)
+	}
+
+	feed := v1.Group("/feed")
+	{
+		feed.GET("/", handlerV1.GetFeed)
 	}
 
 	session := v1.Group("/session")
 	{
 		session.GET("/list", handlerV1.GetSessions)
+		session.GET("/me", handlerV1.GetCurrentSession)
+		session.POST("/revoke-others", handlerV1.RevokeOtherSessions)
 		session.GET("/:id", handlerV1.GetSession)
 		session.PUT("/", handlerV1.UpdateSession)
 		session.DELETE("/:id", handlerV1.DeleteSession)
@@ -80,8 +109,134 @@ func NewRouter(engine *gin.Engine, l *logger.Logger, config *config.Config, useC
 	business := v1.Group("/business")
 	{
 		business.POST("/", handlerV1.CreateBusiness)
+		business.GET("/list", handlerV1.GetBusinesses)
 		business.GET("/:id", handlerV1.GetBusiness)
 		business.PUT("/", handlerV1.UpdateBusiness)
 		business.DELETE("/:id", handlerV1.DeleteBusiness)
+		business.POST("/:id/checkin", handlerV1.CheckIn)
+		business.GET("/:id/menus", handlerV1.GetMenus)
+		business.POST("/:id/menus", handlerV1.CreateMenu)
+		business.GET("/menus/:id", handlerV1.GetMenu)
+		business.PUT("/menus", handlerV1.UpdateMenu)
+		business.DELETE("/menus/:id", handlerV1.DeleteMenu)
+		business.PUT("/:id/attributes", handlerV1.SetBusinessAttributes)
+		business.PUT("/:id/hours", handlerV1.SetOpeningHours)
+		business.GET("/:id/reservation-settings", handlerV1.GetReservationSettings)
+		business.PUT("/:id/reservation-settings", handlerV1.UpdateReservationSettings)
+		business.GET("/:id/availability", handlerV1.GetAvailability)
+		business.GET("/:id/calendar", handlerV1.GetReservationCalendar)
+		business.GET("/:id/promotions", handlerV1.GetBusinessPromotions)
+		business.POST("/:id/promotions", handlerV1.CreatePromotion)
+		business.GET("/promotions/:id", handlerV1.GetPromotion)
+		business.PUT("/promotions", handlerV1.UpdatePromotion)
+		business.DELETE("/promotions/:id", handlerV1.DeletePromotion)
+		business.GET("/:id/suggestions", handlerV1.GetEditSuggestions)
+		business.POST("/:id/suggestions", handlerV1.CreateEditSuggestion)
+		business.GET("/suggestions/:id", handlerV1.GetEditSuggestion)
+		business.POST("/suggestions/:id/approve", handlerV1.ApproveEditSuggestion)
+		business.POST("/suggestions/:id/reject", handlerV1.RejectEditSuggestion)
+		business.GET("/:id/revisions", handlerV1.GetBusinessRevisions)
+		business.GET("/:id/revisions/diff", handlerV1.DiffBusinessRevisions)
+		business.POST("/:id/rollback", handlerV1.RollbackBusiness)
+	}
+
+	question := v1.Group("/question")
+	{
+		question.POST("/", handlerV1.CreateQuestion)
+		question.GET("/list", handlerV1.GetQuestions)
+		question.GET("/:id", handlerV1.GetQuestion)
+		question.DELETE("/:id", handlerV1.DeleteQuestion)
+		question.POST("/:id/upvote", handlerV1.UpvoteQuestion)
+		question.GET("/:id/answers", handlerV1.GetAnswers)
+		question.POST("/:id/answers", handlerV1.CreateAnswer)
+		question.DELETE("/answers/:id", handlerV1.DeleteAnswer)
+		question.POST("/answers/:id/upvote", handlerV1.UpvoteAnswer)
+		question.POST("/answers/:id/accept", handlerV1.AcceptAnswer)
+	}
+
+	promotion := v1.Group("/promotion")
+	{
+		promotion.GET("/list", handlerV1.GetPromotions)
+	}
+
+	reservation := v1.Group("/reservation")
+	{
+		reservation.POST("/", handlerV1.CreateReservation)
+		reservation.GET("/list", handlerV1.GetReservations)
+		reservation.PUT("/", handlerV1.RescheduleReservation)
+		reservation.POST("/:id/cancel", handlerV1.CancelReservation)
+	}
+
+	category := v1.Group("/category")
+	{
+		category.GET("/tree", handlerV1.GetCategoryTree)
+	}
+
+	attribute := v1.Group("/attribute")
+	{
+		attribute.GET("/list", handlerV1.GetAttributes)
+	}
+
+	review := v1.Group("/review")
+	{
+		review.POST("/", handlerV1.CreateReview)
+		review.GET("/list", handlerV1.GetReviews)
+		review.GET("/:id", handlerV1.GetReview)
+		review.PUT("/", handlerV1.UpdateReview)
+		review.DELETE("/:id", handlerV1.DeleteReview)
+		review.POST("/:id/reply", handlerV1.CreateReviewReply)
+		review.PUT("/:id/reply", handlerV1.UpdateReviewReply)
+		review.DELETE("/:id/reply", handlerV1.DeleteReviewReply)
+		review.POST("/:id/vote", handlerV1.VoteReview)
+	}
+
+	photo := v1.Group("/photo")
+	{
+		photo.POST("/", handlerV1.CreatePhoto)
+		photo.GET("/list", handlerV1.GetPhotos)
+		photo.DELETE("/:id", handlerV1.DeletePhoto)
+	}
+
+	report := v1.Group("/report")
+	{
+		report.POST("/", handlerV1.CreateReport)
+	}
+
+	admin := v1.Group("/admin")
+	{
+		admin.POST("/user/:id/block", handlerV1.BlockUser)
+		admin.POST("/user/:id/unblock", handlerV1.UnblockUser)
+		admin.PUT("/user/:id/role", handlerV1.UpdateUserRole)
+
+		admin.GET("/policy/list", handlerV1.GetPolicies)
+		admin.POST("/policy", handlerV1.CreatePolicy)
+		admin.DELETE("/policy", handlerV1.DeletePolicy)
+		admin.POST("/policy/role", handlerV1.CreateRoleInheritance)
+		admin.DELETE("/policy/role", handlerV1.DeleteRoleInheritance)
+
+		admin.POST("/attribute", handlerV1.CreateAttribute)
+		admin.PUT("/attribute", handlerV1.UpdateAttribute)
+		admin.DELETE("/attribute/:id", handlerV1.DeleteAttribute)
+
+		admin.POST("/category", handlerV1.CreateCategory)
+		admin.PUT("/category", handlerV1.UpdateCategory)
+		admin.DELETE("/category/:id", handlerV1.DeleteCategory)
+
+		admin.GET("/business/:id/duplicates", handlerV1.GetBusinessDuplicates)
+		admin.POST("/business/merge", handlerV1.MergeBusinesses)
+
+		moderation := admin.Group("/moderation")
+		{
+			moderation.GET("/list", handlerV1.GetModerationCases)
+			moderation.GET("/:id", handlerV1.GetModerationCase)
+			moderation.POST("/:id/claim", handlerV1.ClaimModerationCase)
+			moderation.POST("/:id/action", handlerV1.ActionModerationCase)
+			moderation.POST("/:id/dismiss", handlerV1.DismissModerationCase)
+		}
+	}
+
+	audit := v1.Group("/audit")
+	{
+		audit.GET("/", handlerV1.GetAuditLogs)
 	}
 }
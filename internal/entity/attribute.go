@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Attribute value types
+const (
+	AttributeTypeBool   = "bool"
+	AttributeTypeEnum   = "enum"
+	AttributeTypeNumber = "number"
+)
+
+// Attribute is an entry of the admin-managed catalog of business attributes, e.g. Wi-Fi or parking.
+// Businesses store their values by Key, e.g. {"wifi": "free", "outdoor_seating": true, "seats": 40}.
+type Attribute struct {
+	ID          string   `json:"id"`
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options"` // allowed values of enum attributes
+	Unit        string   `json:"unit"`    // shown after number values, e.g. "seats"
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type AttributeList struct {
+	Items []Attribute `json:"attributes"`
+	Count int         `json:"count"`
+}
+
+type CreateAttributeRequest struct {
+	Key         string   `json:"key" binding:"required,max=64,slug"`
+	Name        string   `json:"name" binding:"required,max=100"`
+	Description string   `json:"description" binding:"max=500"`
+	Type        string   `json:"type" binding:"required,attribute_type"`
+	Options     []string `json:"options" binding:"required_if=Type enum,max=50,dive,max=64,slug"`
+	Unit        string   `json:"unit" binding:"max=32"`
+}
+
+// UpdateAttributeRequest can't change the key or type, businesses already store values of them
+type UpdateAttributeRequest struct {
+	ID          string   `json:"id" binding:"required,uuid"`
+	Name        string   `json:"name" binding:"required,max=100"`
+	Description string   `json:"description" binding:"max=500"`
+	Options     []string `json:"options" binding:"max=50,dive,max=64,slug"`
+	Unit        string   `json:"unit" binding:"max=32"`
+}
+
+// BusinessAttributesRequest replaces all attribute values of a business, keyed by Attribute.Key
+type BusinessAttributesRequest struct {
+	Attributes map[string]interface{} `json:"attributes" binding:"required"`
+}
+
+// CheckValue reports why a value, as decoded from JSON, can't be stored for the attribute, or "" if it can.
+func (a Attribute) CheckValue(value interface{}) string {
+	switch a.Type {
+	case AttributeTypeBool:
+		if _, ok := value.(bool); ok {
+			return ""
+		}
+		return "must be true or false"
+	case AttributeTypeEnum:
+		if s, ok := value.(string); ok && containsString(a.Options, s) {
+			return ""
+		}
+		return "must be one of: " + strings.Join(a.Options, ", ")
+	case AttributeTypeNumber:
+		if _, ok := value.(float64); ok {
+			return ""
+		}
+		return "must be a number"
+	}
+
+	return "has an unknown type"
+}
+
+// FilterPath turns a business list filter on the attribute into a jsonpath predicate on the business attributes.
+// The filter is a value, e.g. "street", optionally prefixed by a comparison for numbers, e.g. ">=20".
+// Bool attributes match true without a value. Key and enum options are slugs, so they are safe to put in the path.
+func (a Attribute) FilterPath(filter string) (string, error) {
+	switch a.Type {
+	case AttributeTypeBool:
+		switch filter {
+		case "", "true":
+			return fmt.Sprintf("$.%s ? (@ == true)", a.Key), nil
+		case "false":
+			return fmt.Sprintf("$.%s ? (@ == false)", a.Key), nil
+		}
+		return "", fmt.Errorf("%s must be true or false", a.Key)
+	case AttributeTypeEnum:
+		if !containsString(a.Options, filter) {
+			return "", fmt.Errorf("%s must be one of: %s", a.Key, strings.Join(a.Options, ", "))
+		}
+		return fmt.Sprintf("$.%s ? (@ == \"%s\")", a.Key, filter), nil
+	case AttributeTypeNumber:
+		op := "=="
+		for _, prefix := range []string{">=", "<=", ">", "<"} {
+			if strings.HasPrefix(filter, prefix) {
+				op, filter = prefix, strings.TrimPrefix(filter, prefix)
+				break
+			}
+		}
+
+		n, err := strconv.ParseFloat(filter, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", fmt.Errorf("%s must be a number, optionally prefixed by >=, <=, > or <", a.Key)
+		}
+		return fmt.Sprintf("$.%s ? (@ %s %s)", a.Key, op, strconv.FormatFloat(n, 'f', -1, 64)), nil
+	}
+
+	return "", fmt.Errorf("%s has an unknown type", a.Key)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
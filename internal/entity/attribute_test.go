@@ -0,0 +1,72 @@
+package entity
+
+import "testing"
+
+func TestAttribute_CheckValue(t *testing.T) {
+	wifi := Attribute{Key: "wifi", Type: AttributeTypeEnum, Options: []string{"free", "paid"}}
+	seats := Attribute{Key: "seats", Type: AttributeTypeNumber}
+	cards := Attribute{Key: "accepts_cards", Type: AttributeTypeBool}
+
+	tests := []struct {
+		attribute Attribute
+		value     interface{}
+		valid     bool
+	}{
+		{wifi, "free", true},
+		{wifi, "fast", false},
+		{wifi, true, false},
+		{seats, float64(40), true},
+		{seats, "40", false},
+		{cards, false, true},
+		{cards, "yes", false},
+	}
+
+	for _, tt := range tests {
+		if valid := tt.attribute.CheckValue(tt.value) == ""; valid != tt.valid {
+			t.Errorf("%s.CheckValue(%v) valid = %v, expected %v", tt.attribute.Key, tt.value, valid, tt.valid)
+		}
+	}
+}
+
+func TestAttribute_FilterPath(t *testing.T) {
+	parking := Attribute{Key: "parking", Type: AttributeTypeEnum, Options: []string{"street", "lot"}}
+	seats := Attribute{Key: "seats", Type: AttributeTypeNumber}
+	wifi := Attribute{Key: "wifi", Type: AttributeTypeBool}
+
+	tests := []struct {
+		attribute Attribute
+		filter    string
+		expected  string
+	}{
+		{wifi, "", "$.wifi ? (@ == true)"},
+		{wifi, "false", "$.wifi ? (@ == false)"},
+		{parking, "lot", `$.parking ? (@ == "lot")`},
+		{seats, ">=20", "$.seats ? (@ >= 20)"},
+		{seats, "<2.5", "$.seats ? (@ < 2.5)"},
+		{seats, "12", "$.seats ? (@ == 12)"},
+	}
+
+	for _, tt := range tests {
+		path, err := tt.attribute.FilterPath(tt.filter)
+		if err != nil {
+			t.Fatalf("%s:%s: %v", tt.attribute.Key, tt.filter, err)
+		}
+		if path != tt.expected {
+			t.Errorf("%s:%s = %q, expected %q", tt.attribute.Key, tt.filter, path, tt.expected)
+		}
+	}
+
+	for _, filter := range []struct {
+		attribute Attribute
+		filter    string
+	}{
+		{wifi, "maybe"},
+		{parking, `lot") || (@ == "x`},
+		{seats, ">=NaN"},
+		{seats, "1 || true"},
+	} {
+		if _, err := filter.attribute.FilterPath(filter.filter); err == nil {
+			t.Errorf("expected %s:%s to be rejected", filter.attribute.Key, filter.filter)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package entity
+
+// Audit log actions
+const (
+	AuditActionLogin         = "auth.login"
+	AuditActionLoginFailed   = "auth.login_failed"
+	AuditActionLogout        = "auth.logout"
+	AuditActionVerifyEmail   = "auth.verify_email"
+	AuditActionUserCreate    = "user.create"
+	AuditActionUserUpdate    = "user.update"
+	AuditActionUserDelete    = "user.delete"
+	AuditActionUserBlock     = "user.block"
+	AuditActionUserUnblock   = "user.unblock"
+	AuditActionUserRole      = "user.role_change"
+	AuditActionSessionUpdate = "session.update"
+	AuditActionSessionDelete = "session.delete"
+	AuditActionSessionRevoke = "session.revoke_others"
+	AuditActionPolicyAdd     = "policy.add"
+	AuditActionPolicyRemove  = "policy.remove"
+	AuditActionRoleAdd       = "policy.role_add"
+	AuditActionRoleRemove    = "policy.role_remove"
+	AuditActionBusinessMerge = "business.merge"
+	AuditActionBusinessEdit  = "business.edit"
+
+	AuditTargetUser     = "user"
+	AuditTargetSession  = "session"
+	AuditTargetPolicy   = "policy"
+	AuditTargetBusiness = "business"
+)
+
+// AuditLog is a single append-only record of an administrative or security-relevant action
+type AuditLog struct {
+	ID         string                 `json:"id"`
+	ActorID    string                 `json:"actor_id"`
+	SessionID  string                 `json:"session_id"`
+	IPAddress  string                 `json:"ip_address"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	Diff       map[string]FieldChange `json:"diff"`
+	CreatedAt  string                 `json:"created_at"`
+}
+
+// FieldChange holds the value of a single field before and after an action
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type AuditLogList struct {
+	Items []AuditLog `json:"audit_logs"`
+	Count int        `json:"count"`
+}
@@ -1,19 +1,51 @@
 package entity
 
+// Platforms a session can be created from, matching the platform enum
+const (
+	PlatformAdmin  = "admin"
+	PlatformWeb    = "web"
+	PlatformMobile = "mobile"
+)
+
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Platform string `json:"platform"` // consider using the Platform constants for type safety
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+	Platform string `json:"platform" binding:"required,platform"`
 }
 
 type RegisterRequest struct {
-	FullName string `json:"full_name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	FullName string `json:"full_name" binding:"required,max=255"`
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,password"`
 }
 
 type VerifyEmail struct {
-	Email    string `json:"email"`
-	Otp      string `json:"otp"`
-	Platform string `json:"platform"` // consider using the Platform constants for type safety
+	Email    string `json:"email" binding:"required,email"`
+	Otp      string `json:"otp" binding:"required,len=6,numeric"`
+	Platform string `json:"platform" binding:"required,platform"`
+}
+
+// AuthResponse is returned after a successful login or email verification
+type AuthResponse struct {
+	User    UserResponse `json:"user"`
+	Session Session      `json:"session"`
+}
+
+// Principal is the authenticated caller of a request, built from verified JWT claims only
+type Principal struct {
+	UserID    string `json:"sub"`
+	UserRole  string `json:"user_role"`
+	UserType  string `json:"user_type"`
+	Platform  string `json:"platform"`
+	SessionID string `json:"session_id"`
+}
+
+// IsAuthenticated reports whether the request carried a valid token
+func (p Principal) IsAuthenticated() bool {
+	return p.UserID != "" && p.SessionID != ""
+}
+
+// IsAdmin reports whether the principal manages every resource regardless of ownership
+func (p Principal) IsAdmin() bool {
+	return p.UserRole == UserRoleAdmin || p.UserRole == UserRoleSuperAdmin
 }
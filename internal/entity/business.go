@@ -1,33 +1,66 @@
 package entity
 
-// Business category options
-const (
-	CategoryRestaurant    = "Restaurant"
-	CategoryRetail        = "Retail"
-	CategoryService       = "Service"
-	CategoryHealthcare    = "Healthcare"
-	CategoryEntertainment = "Entertainment"
-)
+import "math"
 
 // Business entity
 type Business struct {
-	ID                 string   `json:"id"`
-	Name               string   `json:"name"`
-	Location           Location `json:"location"`
-	Category           string   `json:"category"`
-	Description        string   `json:"description"`
-	ContactInformation string   `json:"contact_information"`
-	Attachments        []string `json:"attachments"`
-	CreatedBy          string   `json:"created_by"`
-	CreatedAt          string   `json:"created_at"`
-	UpdatedAt          string   `json:"updated_at"`
-	DeletedAt          string   `json:"deleted_at,omitempty"` // can be null
+	ID                 string                 `json:"id"`
+	Name               string                 `json:"name" binding:"required,max=255"`
+	Location           Location               `json:"location"`
+	Categories         []string               `json:"categories" binding:"required,min=1,max=3,dive,slug"` // category slugs, see CategoryTree
+	CategoryIDs        []string               `json:"-"`
+	Description        string                 `json:"description"`
+	ContactInformation string                 `json:"contact_information"`
+	Attachments        []string               `json:"attachments"`
+	PriceLevel         int                    `json:"price_level" binding:"min=0,max=4"` // 1 ($) to 4 ($$$$), 0 when unknown
+	Attributes         map[string]interface{} `json:"attributes"`                        // by Attribute.Key, set with PUT /business/{id}/attributes
+	OpeningHours       OpeningHours           `json:"opening_hours" binding:"-"`         // set with PUT /business/{id}/hours
+	Status             string                 `json:"status"`
+	MergedInto         string                 `json:"merged_into,omitempty"` // set when an admin merged this duplicate into another business
+	ClosedPermanently  bool                   `json:"closed_permanently"`    // set by approving an EditSuggestion
+	CheckInCount       int                    `json:"checkin_count"`
+	TextHash           string                 `json:"-"`          // contentfilter.Fingerprint of the name and description
+	Bookmarked         bool                   `json:"bookmarked"` // saved in a collection of the current principal
+	CreatedBy          string                 `json:"created_by"`
+	UpdatedBy          string                 `json:"-"` // author of the revision BusinessRepo.Update records
+	CreatedAt          string                 `json:"created_at"`
+	UpdatedAt          string                 `json:"updated_at"`
+	DeletedAt          string                 `json:"deleted_at,omitempty"` // can be null
 }
 
 // Location entity for latitude and longitude
 type Location struct {
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude" binding:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" binding:"min=-180,max=180"`
+}
+
+const earthRadiusMeters = 6371000
+
+// DistanceTo is the great-circle distance between two locations in meters.
+func (l Location) DistanceTo(other Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLong := (other.Longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
+
+// BoundingBox returns the south-west and north-east corners of a box that contains every location
+// within radius meters. It is meant to narrow down a search, corners near the poles are clamped.
+func (l Location) BoundingBox(radius float64) (Location, Location) {
+	dLat := radius / earthRadiusMeters * 180 / math.Pi
+	dLong := 180.0
+	if cos := math.Cos(l.Latitude * math.Pi / 180); cos > dLat/180 {
+		dLong = math.Min(dLat/cos, 180)
+	}
+
+	southWest := Location{Latitude: math.Max(l.Latitude-dLat, -90), Longitude: math.Max(l.Longitude-dLong, -180)}
+	northEast := Location{Latitude: math.Min(l.Latitude+dLat, 90), Longitude: math.Min(l.Longitude+dLong, 180)}
+
+	return southWest, northEast
 }
 
 // Request parameters for single business entity actions
@@ -35,6 +68,42 @@ type BusinessSingleRequest struct {
 	ID string `json:"id"`
 }
 
+// DuplicateCandidate is an existing business that may be the same place as a new one
+type DuplicateCandidate struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Location       Location `json:"location"`
+	Similarity     float64  `json:"similarity"` // of the names, from 0 to 1
+	DistanceMeters float64  `json:"distance_meters"`
+}
+
+// DuplicateWarning is returned instead of creating a business that looks like one that already exists
+type DuplicateWarning struct {
+	Message    string               `json:"message"`
+	Code       string               `json:"code"`
+	Candidates []DuplicateCandidate `json:"candidates"`
+}
+
+// MergeBusinessRequest merges a duplicate business into the one that stays
+type MergeBusinessRequest struct {
+	SourceID string `json:"source_id" binding:"required,uuid"`
+	TargetID string `json:"target_id" binding:"required,uuid,nefield=SourceID"`
+}
+
+// MergeBusinessResult counts what was moved from the duplicate to the business that stays,
+// and the reviews and bookmarks of the duplicate dropped because the business that stays already had them
+type MergeBusinessResult struct {
+	SourceID         string `json:"source_id"`
+	TargetID         string `json:"target_id"`
+	Reviews          int    `json:"reviews"`
+	Photos           int    `json:"photos"`
+	Bookmarks        int    `json:"bookmarks"`
+	CheckIns         int    `json:"checkins"`
+	Questions        int    `json:"questions"`
+	DroppedReviews   int    `json:"dropped_reviews"`
+	DroppedBookmarks int    `json:"dropped_bookmarks"`
+}
+
 // Response structure for a list of businesses
 type BusinessList struct {
 	Items []Business `json:"businesses"`
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocation_DistanceTo(t *testing.T) {
+	tashkent := Location{Latitude: 41.2995, Longitude: 69.2401}
+	samarkand := Location{Latitude: 39.6542, Longitude: 66.9597}
+
+	if d := tashkent.DistanceTo(tashkent); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+
+	// about 266 km as the crow flies
+	if d := tashkent.DistanceTo(samarkand); math.Abs(d-266000) > 2000 {
+		t.Fatalf("unexpected distance %f", d)
+	}
+
+	// 0.001 degree of latitude is about 111 meters
+	nearby := Location{Latitude: tashkent.Latitude + 0.001, Longitude: tashkent.Longitude}
+	if d := tashkent.DistanceTo(nearby); math.Abs(d-111) > 1 {
+		t.Fatalf("unexpected distance %f", d)
+	}
+}
+
+func TestLocation_BoundingBox(t *testing.T) {
+	tashkent := Location{Latitude: 41.2995, Longitude: 69.2401}
+
+	southWest, northEast := tashkent.BoundingBox(5000)
+
+	// the box reaches 5 km north and east, and no further
+	north := Location{Latitude: northEast.Latitude, Longitude: tashkent.Longitude}
+	east := Location{Latitude: tashkent.Latitude, Longitude: northEast.Longitude}
+	for _, corner := range []Location{north, east} {
+		if d := tashkent.DistanceTo(corner); math.Abs(d-5000) > 50 {
+			t.Fatalf("expected the box edge 5000 m away, got %f", d)
+		}
+	}
+
+	if southWest.Latitude >= tashkent.Latitude || southWest.Longitude >= tashkent.Longitude {
+		t.Fatalf("expected the south-west corner below and left of the center, got %+v", southWest)
+	}
+
+	if southWest, northEast := (Location{Latitude: 89.99}).BoundingBox(5000); northEast.Latitude != 90 || southWest.Longitude != -180 {
+		t.Fatalf("expected the box to be clamped near the pole, got %+v %+v", southWest, northEast)
+	}
+}
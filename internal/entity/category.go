@@ -0,0 +1,95 @@
+package entity
+
+import "sort"
+
+// DefaultLanguage is the language every category has a name in, used when the requested one is missing
+const DefaultLanguage = "en"
+
+// Category is a node of the category tree, e.g. Restaurant → Italian → Pizza
+type Category struct {
+	ID        string            `json:"id"`
+	ParentID  string            `json:"parent_id,omitempty"` // empty for top-level categories
+	Slug      string            `json:"slug"`
+	Name      string            `json:"name"`  // in the requested language
+	Names     map[string]string `json:"names"` // by language code, e.g. {"en": "Italian", "uz": "Italyan"}
+	Icon      string            `json:"icon"`
+	Position  int               `json:"position"` // siblings are listed by position
+	Children  []Category        `json:"children,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+type CategoryList struct {
+	Items []Category `json:"categories"`
+	Count int        `json:"count"`
+}
+
+type CreateCategoryRequest struct {
+	ParentID string            `json:"parent_id" binding:"omitempty,uuid"`
+	Slug     string            `json:"slug" binding:"required,max=64,slug"`
+	Names    map[string]string `json:"names" binding:"required,max=20,dive,keys,len=2,endkeys,required,max=100"`
+	Icon     string            `json:"icon" binding:"max=255"`
+	Position int               `json:"position" binding:"min=0"`
+}
+
+type UpdateCategoryRequest struct {
+	ID       string            `json:"id" binding:"required,uuid"`
+	ParentID string            `json:"parent_id" binding:"omitempty,uuid"`
+	Slug     string            `json:"slug" binding:"required,max=64,slug"`
+	Names    map[string]string `json:"names" binding:"required,max=20,dive,keys,len=2,endkeys,required,max=100"`
+	Icon     string            `json:"icon" binding:"max=255"`
+	Position int               `json:"position" binding:"min=0"`
+}
+
+// LocalName is the name of the category in the given language, falling back to DefaultLanguage and then the slug.
+func (c Category) LocalName(lang string) string {
+	if name, ok := c.Names[lang]; ok {
+		return name
+	}
+	if name, ok := c.Names[DefaultLanguage]; ok {
+		return name
+	}
+	return c.Slug
+}
+
+// CategoryTree nests a flat list of categories under their parents, with names in the given language.
+func CategoryTree(categories []Category, lang string) []Category {
+	children := make(map[string][]Category, len(categories))
+	for _, category := range categories {
+		category.Name = category.LocalName(lang)
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+
+	var build func(parentID string) []Category
+	build = func(parentID string) []Category {
+		nodes := children[parentID]
+		sort.SliceStable(nodes, func(i, j int) bool {
+			if nodes[i].Position != nodes[j].Position {
+				return nodes[i].Position < nodes[j].Position
+			}
+			return nodes[i].Slug < nodes[j].Slug
+		})
+
+		for i := range nodes {
+			nodes[i].Children = build(nodes[i].ID)
+		}
+		return nodes
+	}
+
+	return build("")
+}
+
+// CategoryDescendants returns the ID of the category and of every category below it.
+func CategoryDescendants(categories []Category, id string) []string {
+	children := make(map[string][]string, len(categories))
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category.ID)
+	}
+
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+
+	return ids
+}
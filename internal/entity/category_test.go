@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var testCategories = []Category{
+	{ID: "pizza", ParentID: "italian", Slug: "pizza", Names: map[string]string{"en": "Pizza"}},
+	{ID: "restaurant", Slug: "restaurant", Names: map[string]string{"en": "Restaurant", "uz": "Restoran"}},
+	{ID: "retail", Slug: "retail", Position: 1, Names: map[string]string{"en": "Retail"}},
+	{ID: "italian", ParentID: "restaurant", Slug: "italian", Names: map[string]string{"en": "Italian"}},
+	{ID: "sushi", ParentID: "restaurant", Slug: "sushi"},
+}
+
+func TestCategoryTree(t *testing.T) {
+	tree := CategoryTree(testCategories, "uz")
+
+	if len(tree) != 2 || tree[0].Slug != "restaurant" || tree[1].Slug != "retail" {
+		t.Fatalf("unexpected roots %+v", tree)
+	}
+	if tree[0].Name != "Restoran" || tree[1].Name != "Retail" {
+		t.Fatalf("unexpected names %q, %q", tree[0].Name, tree[1].Name)
+	}
+
+	restaurant := tree[0]
+	if len(restaurant.Children) != 2 || restaurant.Children[0].Slug != "italian" || restaurant.Children[1].Name != "sushi" {
+		t.Fatalf("unexpected children %+v", restaurant.Children)
+	}
+	if pizza := restaurant.Children[0].Children; len(pizza) != 1 || pizza[0].Slug != "pizza" {
+		t.Fatalf("unexpected grandchildren %+v", pizza)
+	}
+}
+
+func TestCategoryDescendants(t *testing.T) {
+	ids := CategoryDescendants(testCategories, "restaurant")
+	sort.Strings(ids)
+
+	expected := []string{"italian", "pizza", "restaurant", "sushi"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+
+	if ids := CategoryDescendants(testCategories, "pizza"); !reflect.DeepEqual(ids, []string{"pizza"}) {
+		t.Fatalf("expected a leaf to be its own only descendant, got %v", ids)
+	}
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "errors"
+
+// Check-in limits, returned when a check-in would break them
+var (
+	ErrCheckInCooldown   = errors.New("already checked in at this business recently")
+	ErrCheckInDailyLimit = errors.New("daily check-in limit reached")
+)
+
+// CheckIn is a visit of a user to a business. Only the distance to the business is kept, not where the user was.
+type CheckIn struct {
+	ID             string  `json:"id"`
+	UserID         string  `json:"user_id"`
+	BusinessID     string  `json:"business_id"`
+	BusinessName   string  `json:"business_name"`
+	DistanceMeters float64 `json:"distance_meters"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type CheckInList struct {
+	Items []CheckIn `json:"checkins"`
+	Count int       `json:"count"`
+}
+
+type CheckInRequest struct {
+	Location Location `json:"location"` // where the user is now
+}
@@ -0,0 +1,51 @@
+package entity
+
+// Collection is a named list of businesses a user saved, e.g. "Lunch spots"
+type Collection struct {
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+	ItemCount   int    `json:"item_count"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type CollectionList struct {
+	Items []Collection `json:"collections"`
+	Count int          `json:"count"`
+}
+
+type CreateCollectionRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+type UpdateCollectionRequest struct {
+	ID          string `json:"id" binding:"required,uuid"`
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// CollectionItem is a business saved in a collection. UserID is the collection owner.
+type CollectionItem struct {
+	CollectionID string   `json:"collection_id"`
+	BusinessID   string   `json:"business_id"`
+	UserID       string   `json:"user_id"`
+	Note         string   `json:"note"`
+	Business     Business `json:"business"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+type CollectionItemList struct {
+	Items []CollectionItem `json:"items"`
+	Count int              `json:"count"`
+}
+
+type CollectionItemRequest struct {
+	BusinessID string `json:"business_id" binding:"required,uuid"`
+	Note       string `json:"note" binding:"max=500"`
+}
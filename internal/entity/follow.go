@@ -0,0 +1,53 @@
+package entity
+
+// Feed item types
+const (
+	FeedItemReview  = "review"
+	FeedItemCheckIn = "checkin"
+	FeedItemPhoto   = "photo"
+)
+
+type Follow struct {
+	FollowerID string `json:"follower_id"`
+	FolloweeID string `json:"followee_id"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// FollowUser is an entry of a follower or following list
+type FollowUser struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+	FollowedAt  string `json:"followed_at"`
+}
+
+type FollowUserList struct {
+	Items []FollowUser `json:"users"`
+	Count int          `json:"count"`
+}
+
+// FeedRequest is a page of the feed of a user. The page starts after the item the cursor points at, if any.
+type FeedRequest struct {
+	UserID          string
+	Limit           int
+	CursorCreatedAt string
+	CursorID        string
+}
+
+// FeedItem is a review, check-in or photo of a followed user. Rating, Text and URL are set depending on Type.
+type FeedItem struct {
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	UserID       string `json:"user_id"`
+	BusinessID   string `json:"business_id"`
+	BusinessName string `json:"business_name"`
+	Rating       int    `json:"rating,omitempty"`
+	Text         string `json:"text,omitempty"`
+	URL          string `json:"url,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type Feed struct {
+	Items      []FeedItem `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"` // pass as cursor to get the next page
+}
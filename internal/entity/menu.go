@@ -0,0 +1,81 @@
+package entity
+
+// Price levels of a business, shown as $ to $$$$
+const (
+	PriceLevelMin = 1
+	PriceLevelMax = 4
+)
+
+// Menu kind options
+const (
+	MenuKindMenu     = "menu"     // food and drinks
+	MenuKindServices = "services" // e.g. a price list of a salon or a garage
+)
+
+// Dietary tag options of menu items
+const (
+	DietaryVegetarian = "vegetarian"
+	DietaryVegan      = "vegan"
+	DietaryGlutenFree = "gluten_free"
+	DietaryDairyFree  = "dairy_free"
+	DietaryNutFree    = "nut_free"
+	DietaryHalal      = "halal"
+	DietaryKosher     = "kosher"
+	DietarySpicy      = "spicy"
+)
+
+// Menu is a catalog of what a business offers, split into sections. All prices are in Currency.
+type Menu struct {
+	ID          string        `json:"id"`
+	BusinessID  string        `json:"business_id"`
+	Kind        string        `json:"kind"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Currency    string        `json:"currency"` // ISO 4217 code, e.g. "USD"
+	Position    int           `json:"position"` // menus of a business are listed by position
+	Sections    []MenuSection `json:"sections"`
+	CreatedAt   string        `json:"created_at"`
+	UpdatedAt   string        `json:"updated_at"`
+}
+
+type MenuSection struct {
+	ID          string     `json:"id" binding:"omitempty,uuid"` // generated when empty
+	Name        string     `json:"name" binding:"required,max=100"`
+	Description string     `json:"description" binding:"max=500"`
+	Items       []MenuItem `json:"items" binding:"max=200,dive"`
+}
+
+type MenuItem struct {
+	ID          string   `json:"id" binding:"omitempty,uuid"` // generated when empty
+	Name        string   `json:"name" binding:"required,max=255"`
+	Description string   `json:"description" binding:"max=1000"`
+	Price       int64    `json:"price" binding:"min=0"` // in minor units of the menu currency, e.g. cents
+	DietaryTags []string `json:"dietary_tags" binding:"max=10,dive,dietary_tag"`
+	PhotoID     string   `json:"photo_id" binding:"omitempty,uuid"` // a published photo of the business
+	PhotoURL    string   `json:"photo_url,omitempty"`               // resolved from PhotoID when the menu is read
+}
+
+type MenuList struct {
+	Items []Menu `json:"menus"`
+	Count int    `json:"count"`
+}
+
+type CreateMenuRequest struct {
+	Kind        string        `json:"kind" binding:"required,menu_kind"`
+	Name        string        `json:"name" binding:"required,max=100"`
+	Description string        `json:"description" binding:"max=500"`
+	Currency    string        `json:"currency" binding:"required,iso4217"`
+	Position    int           `json:"position" binding:"min=0"`
+	Sections    []MenuSection `json:"sections" binding:"max=50,dive"`
+}
+
+// UpdateMenuRequest replaces a menu with all its sections and items
+type UpdateMenuRequest struct {
+	ID          string        `json:"id" binding:"required,uuid"`
+	Kind        string        `json:"kind" binding:"required,menu_kind"`
+	Name        string        `json:"name" binding:"required,max=100"`
+	Description string        `json:"description" binding:"max=500"`
+	Currency    string        `json:"currency" binding:"required,iso4217"`
+	Position    int           `json:"position" binding:"min=0"`
+	Sections    []MenuSection `json:"sections" binding:"max=50,dive"`
+}
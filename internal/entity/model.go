@@ -11,7 +11,7 @@ type OrderBy struct {
 
 type Filter struct {
 	Column string `json:"column"`
-	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, search
+	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, in and overlap (comma separated), jsonpath (a jsonb predicate), search
 	Value  string `json:"value"`
 }
 
@@ -37,10 +37,39 @@ type RowsEffected struct {
 }
 
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Message string       `json:"message"`
+	Code    string       `json:"code"`
+	Errors  []FieldError `json:"errors,omitempty"` // set when request validation fails
+}
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
 	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type SuccessResponse struct {
 	Message string `json:"message"`
 }
+
+// Visibility of user-generated content such as businesses, reviews and photos
+const (
+	ContentStatusPublished = "published"
+	ContentStatusPending   = "pending" // held by the content filter until a moderator looks at it
+	ContentStatusHidden    = "hidden"
+)
+
+// Resources that are subject to ownership checks
+const (
+	ResourceUser        = "user"
+	ResourceSession     = "session"
+	ResourceBusiness    = "business"
+	ResourceReview      = "review"
+	ResourcePhoto       = "photo"
+	ResourceCollection  = "collection"
+	ResourceReservation = "reservation"
+	ResourceQuestion    = "question"
+	ResourceAnswer      = "answer"
+	ResourceReply       = "review reply" // identified by the ID of the review it answers
+)
@@ -0,0 +1,87 @@
+package entity
+
+import "errors"
+
+// ErrModerationCaseResolved is returned when moving a case that was resolved in the meantime
+var ErrModerationCaseResolved = errors.New("moderation case was already resolved")
+
+// Moderation case statuses
+const (
+	ModerationStatusOpen      = "open"
+	ModerationStatusInReview  = "in_review"
+	ModerationStatusActioned  = "actioned"
+	ModerationStatusDismissed = "dismissed"
+)
+
+// Moderation actions. Hide, delete, warn and block resolve a case; flag, claim and dismiss only appear in the history.
+const (
+	ModerationActionHide      = "hide"
+	ModerationActionDelete    = "delete"
+	ModerationActionWarnUser  = "warn_user"
+	ModerationActionBlockUser = "block_user"
+	ModerationActionFlag      = "flag" // the content filter held the content, there is no actor
+	ModerationActionClaim     = "claim"
+	ModerationActionDismiss   = "dismiss"
+)
+
+// ModerationCase groups every report about one piece of content
+type ModerationCase struct {
+	ID            string              `json:"id"`
+	TargetType    string              `json:"target_type"`
+	TargetID      string              `json:"target_id"`
+	TargetOwnerID string              `json:"target_owner_id"`
+	Status        string              `json:"status"`
+	ReportCount   int                 `json:"report_count"`
+	AssigneeID    string              `json:"assignee_id"`
+	ReasonCode    string              `json:"reason_code"`
+	Action        string              `json:"action"`
+	Note          string              `json:"note"`
+	Reports       []Report            `json:"reports,omitempty"`
+	History       []ModerationHistory `json:"history,omitempty"`
+	CreatedAt     string              `json:"created_at"`
+	UpdatedAt     string              `json:"updated_at"`
+	ResolvedAt    string              `json:"resolved_at,omitempty"`
+}
+
+// ModerationStep moves a case and its reports to Status and appends History, whose actor becomes the assignee.
+// ContentStatus moves the content of the case to that status if it is in ContentFrom, an empty ContentFrom matching any status.
+type ModerationStep struct {
+	Case          ModerationCase
+	Status        string
+	History       ModerationHistory
+	ContentFrom   string
+	ContentStatus string
+	DeleteContent bool
+}
+
+type ModerationCaseList struct {
+	Items []ModerationCase `json:"cases"`
+	Count int              `json:"count"`
+}
+
+// ModerationHistory is an append-only record of a moderator's step on a case
+type ModerationHistory struct {
+	ID         string `json:"id"`
+	CaseID     string `json:"case_id"`
+	ActorID    string `json:"actor_id"`
+	Action     string `json:"action"`
+	ReasonCode string `json:"reason_code"`
+	Note       string `json:"note"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type ModerationHistoryList struct {
+	Items []ModerationHistory `json:"history"`
+	Count int                 `json:"count"`
+}
+
+type ModerationActionRequest struct {
+	Action     string `json:"action" binding:"required,moderation_action"`
+	ReasonCode string `json:"reason_code" binding:"required,report_reason"`
+	Note       string `json:"note" binding:"max=2000"`
+}
+
+type ModerationDismissRequest struct {
+	ReasonCode string `json:"reason_code" binding:"required,report_reason"`
+	Note       string `json:"note" binding:"max=2000"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+// Photo is a picture of a business uploaded by a user
+type Photo struct {
+	ID         string `json:"id"`
+	BusinessID string `json:"business_id"`
+	UserID     string `json:"user_id"`
+	URL        string `json:"url"`
+	Caption    string `json:"caption"`
+	Status     string `json:"status"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type PhotoList struct {
+	Items []Photo `json:"photos"`
+	Count int     `json:"count"`
+}
+
+type CreatePhotoRequest struct {
+	BusinessID string `json:"business_id" binding:"required,uuid"`
+	URL        string `json:"url" binding:"required,url,max=1024"`
+	Caption    string `json:"caption" binding:"max=500"`
+}
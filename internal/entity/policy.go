@@ -0,0 +1,31 @@
+package entity
+
+// Casbin rule types
+const (
+	PolicyTypePolicy = "p"
+	PolicyTypeRole   = "g"
+)
+
+// PolicyRule is a single row of the casbin_rule table
+type PolicyRule struct {
+	PType string   `json:"ptype"`
+	Rule  []string `json:"rule"`
+}
+
+// Policy allows a subject (role) to perform actions on an object (path)
+type Policy struct {
+	Subject string `json:"subject" binding:"required"`
+	Object  string `json:"object" binding:"required,startswith=/"`
+	Action  string `json:"action" binding:"required"`
+}
+
+// RoleInheritance makes Role inherit every policy of Parent
+type RoleInheritance struct {
+	Role   string `json:"role" binding:"required"`
+	Parent string `json:"parent" binding:"required,nefield=Role"`
+}
+
+type PolicyList struct {
+	Policies []Policy          `json:"policies"`
+	Roles    []RoleInheritance `json:"roles"`
+}
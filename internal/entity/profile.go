@@ -0,0 +1,89 @@
+package entity
+
+import "strings"
+
+// Who can see a part of a profile
+const (
+	ProfileVisibilityPublic    = "public"
+	ProfileVisibilityFollowers = "followers"
+	ProfileVisibilityPrivate   = "private"
+)
+
+// ProfilePrivacy sets who can see each part of a profile. The display name and avatar are always public.
+type ProfilePrivacy struct {
+	About    string `json:"about" binding:"omitempty,profile_visibility"`    // bio and city
+	Stats    string `json:"stats" binding:"omitempty,profile_visibility"`    // ProfileStats
+	CheckIns string `json:"checkins" binding:"omitempty,profile_visibility"` // visit history
+}
+
+// DefaultProfilePrivacy applies to users who never changed their settings
+var DefaultProfilePrivacy = ProfilePrivacy{
+	About:    ProfileVisibilityPublic,
+	Stats:    ProfileVisibilityPublic,
+	CheckIns: ProfileVisibilityPrivate,
+}
+
+type ProfileStats struct {
+	ReviewCount        int     `json:"review_count"`
+	PhotoCount         int     `json:"photo_count"`
+	CheckInCount       int     `json:"checkin_count"`
+	FollowerCount      int     `json:"follower_count"`
+	FollowingCount     int     `json:"following_count"`
+	AverageRatingGiven float64 `json:"average_rating_given"`
+}
+
+// Profile is the public face of a user. It never carries the email or the account status.
+// Bio, City and Stats are left out when the privacy settings hide them; Privacy is only shown to the user themself.
+type Profile struct {
+	ID          string          `json:"id"`
+	DisplayName string          `json:"display_name"`
+	AvatarURL   string          `json:"avatar_url"`
+	Bio         string          `json:"bio,omitempty"`
+	City        string          `json:"city,omitempty"`
+	Stats       *ProfileStats   `json:"stats,omitempty"`
+	Privacy     *ProfilePrivacy `json:"privacy,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+// ProfileRecord is a profile as stored, with everything the privacy settings may hide
+type ProfileRecord struct {
+	ID          string
+	FullName    string
+	DisplayName string
+	AvatarURL   string
+	Bio         string
+	City        string
+	Status      string
+	Privacy     ProfilePrivacy
+	Stats       ProfileStats
+	CreatedAt   string
+}
+
+// PublicName is the name shown on a profile: the display name, or else the first name and the initial
+// of the last name, "Jane D.", so that the full name is never public unless the user chose it.
+func PublicName(displayName, fullName string) string {
+	if displayName != "" {
+		return displayName
+	}
+
+	parts := strings.Fields(fullName)
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	}
+
+	last := []rune(parts[len(parts)-1])
+
+	return parts[0] + " " + string(last[0]) + "."
+}
+
+// UpdateProfileRequest replaces the profile fields of the current user. Privacy is left unchanged when omitted.
+type UpdateProfileRequest struct {
+	DisplayName string          `json:"display_name" binding:"max=100"`
+	AvatarURL   string          `json:"avatar_url" binding:"omitempty,url,max=1024"`
+	Bio         string          `json:"bio" binding:"max=500"`
+	City        string          `json:"city" binding:"max=100"`
+	Privacy     *ProfilePrivacy `json:"privacy"`
+}
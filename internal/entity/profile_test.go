@@ -0,0 +1,21 @@
+package entity
+
+import "testing"
+
+func TestPublicName(t *testing.T) {
+	tests := []struct {
+		displayName, fullName, expected string
+	}{
+		{"Foodie42", "Jane Doe", "Foodie42"},
+		{"", "Jane Doe", "Jane D."},
+		{"", "Jane Mary Ödegaard", "Jane Ö."},
+		{"", "Jane", "Jane"},
+		{"", "  ", ""},
+	}
+
+	for _, tt := range tests {
+		if name := PublicName(tt.displayName, tt.fullName); name != tt.expected {
+			t.Errorf("PublicName(%q, %q) = %q, expected %q", tt.displayName, tt.fullName, name, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Promotion kind options
+const (
+	PromotionKindDeal  = "deal"  // e.g. "20% off Tuesdays"
+	PromotionKindEvent = "event" // e.g. live music on a date
+)
+
+// Promotion is a deal or event a business runs between StartsAt and EndsAt, in UTC.
+// It drops out of listings once it ends.
+type Promotion struct {
+	ID           string `json:"id"`
+	BusinessID   string `json:"business_id"`
+	BusinessName string `json:"business_name"`
+	Kind         string `json:"kind"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Weekdays     []int  `json:"weekdays"` // days of the week it runs on, 0 is Sunday, every day when empty
+	StartsAt     string `json:"starts_at"`
+	EndsAt       string `json:"ends_at"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type PromotionList struct {
+	Items []Promotion `json:"promotions"`
+	Count int         `json:"count"`
+}
+
+type CreatePromotionRequest struct {
+	Kind        string `json:"kind" binding:"required,promotion_kind"`
+	Title       string `json:"title" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	Weekdays    []int  `json:"weekdays" binding:"max=7,dive,min=0,max=6"`
+	StartsAt    string `json:"starts_at" binding:"required"` // RFC 3339, e.g. "2024-05-01T19:00:00+05:00"
+	EndsAt      string `json:"ends_at" binding:"required"`
+}
+
+type UpdatePromotionRequest struct {
+	ID          string `json:"id" binding:"required,uuid"`
+	Kind        string `json:"kind" binding:"required,promotion_kind"`
+	Title       string `json:"title" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	Weekdays    []int  `json:"weekdays" binding:"max=7,dive,min=0,max=6"`
+	StartsAt    string `json:"starts_at" binding:"required"`
+	EndsAt      string `json:"ends_at" binding:"required"`
+}
+
+// PromotionPeriod parses the RFC 3339 start and end of a promotion into UTC.
+// It reports which field is invalid and why, or "" for both if the period is valid.
+func PromotionPeriod(startsAt, endsAt string, maxDays int) (start, end time.Time, field, message string) {
+	start, err := time.Parse(time.RFC3339, startsAt)
+	if err != nil {
+		return start, end, "starts_at", "must be an RFC 3339 time, e.g. 2024-05-01T19:00:00+05:00"
+	}
+
+	end, err = time.Parse(time.RFC3339, endsAt)
+	if err != nil {
+		return start, end, "ends_at", "must be an RFC 3339 time, e.g. 2024-05-01T23:00:00+05:00"
+	}
+
+	switch {
+	case !end.After(start):
+		return start, end, "ends_at", "must be after starts_at"
+	case end.Sub(start) > time.Duration(maxDays)*24*time.Hour:
+		return start, end, "ends_at", fmt.Sprintf("must be at most %d days after starts_at", maxDays)
+	}
+
+	return start.UTC(), end.UTC(), "", ""
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromotionPeriod(t *testing.T) {
+	start, end, field, _ := PromotionPeriod("2024-05-01T19:00:00+05:00", "2024-05-01T23:00:00+05:00", 365)
+	if field != "" {
+		t.Fatalf("expected a valid period, got an invalid %s", field)
+	}
+	if start.Format(time.RFC3339) != "2024-05-01T14:00:00Z" || end.Format(time.RFC3339) != "2024-05-01T18:00:00Z" {
+		t.Fatalf("expected the period in UTC, got %s to %s", start, end)
+	}
+
+	tests := []struct {
+		startsAt, endsAt, field string
+	}{
+		{"tomorrow", "2024-05-01T23:00:00Z", "starts_at"},
+		{"2024-05-01T19:00:00Z", "2024-05-01", "ends_at"},
+		{"2024-05-01T19:00:00Z", "2024-05-01T19:00:00Z", "ends_at"},
+		{"2024-05-01T00:00:00Z", "2025-05-02T00:00:00Z", "ends_at"},
+	}
+
+	for _, tt := range tests {
+		if _, _, field, _ := PromotionPeriod(tt.startsAt, tt.endsAt, 365); field != tt.field {
+			t.Fatalf("expected %s from %s to %s to be invalid, got %q", tt.field, tt.startsAt, tt.endsAt, field)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package entity
+
+// Upvote targets
+const (
+	UpvoteTargetQuestion = "question"
+	UpvoteTargetAnswer   = "answer"
+)
+
+// Question is asked by a user about a business and answered by its owner or other users
+type Question struct {
+	ID               string `json:"id"`
+	BusinessID       string `json:"business_id"`
+	UserID           string `json:"user_id"`
+	Text             string `json:"text"`
+	AnswerCount      int    `json:"answer_count"`
+	UpvoteCount      int    `json:"upvote_count"`
+	AcceptedAnswerID string `json:"accepted_answer_id"` // picked by the owner of the business, can be empty
+	Upvoted          bool   `json:"upvoted"`            // by the current principal
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+type QuestionList struct {
+	Items []Question `json:"questions"`
+	Count int        `json:"count"`
+}
+
+type CreateQuestionRequest struct {
+	BusinessID string `json:"business_id" binding:"required,uuid"`
+	Text       string `json:"text" binding:"required,min=10,max=1000"`
+}
+
+type Answer struct {
+	ID          string `json:"id"`
+	QuestionID  string `json:"question_id"`
+	UserID      string `json:"user_id"`
+	Text        string `json:"text"`
+	UpvoteCount int    `json:"upvote_count"`
+	ByOwner     bool   `json:"by_owner"` // written by the owner of the business
+	Accepted    bool   `json:"accepted"`
+	Upvoted     bool   `json:"upvoted"` // by the current principal
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type AnswerList struct {
+	Items []Answer `json:"answers"`
+	Count int      `json:"count"`
+}
+
+type CreateAnswerRequest struct {
+	Text string `json:"text" binding:"required,max=2000"`
+}
+
+// Upvote is a user's upvote on a question or an answer
+type Upvote struct {
+	Target   string `json:"target"` // UpvoteTargetQuestion or UpvoteTargetAnswer
+	TargetID string `json:"target_id"`
+	UserID   string `json:"user_id"`
+}
+
+// UpvoteResponse tells whether the upvote is now set, along with the updated counter
+type UpvoteResponse struct {
+	Upvoted     bool `json:"upvoted"`
+	UpvoteCount int  `json:"upvote_count"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+// Content that can be reported
+const (
+	ReportTargetBusiness    = "business"
+	ReportTargetReview      = "review"
+	ReportTargetReviewReply = "review_reply"
+	ReportTargetPhoto       = "photo"
+)
+
+// Report reason codes
+const (
+	ReportReasonSpam       = "spam"
+	ReportReasonOffensive  = "offensive"
+	ReportReasonHarassment = "harassment"
+	ReportReasonFalseInfo  = "false_information"
+	ReportReasonConflict   = "conflict_of_interest"
+	ReportReasonOther      = "other"
+)
+
+// Report is a user's complaint about a piece of content
+type Report struct {
+	ID         string `json:"id"`
+	CaseID     string `json:"case_id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	ReporterID string `json:"reporter_id"`
+	ReasonCode string `json:"reason_code"`
+	Details    string `json:"details"`
+	Status     string `json:"status"` // follows the status of its moderation case
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type ReportList struct {
+	Items []Report `json:"reports"`
+	Count int      `json:"count"`
+}
+
+type CreateReportRequest struct {
+	TargetType string `json:"target_type" binding:"required,report_target"`
+	TargetID   string `json:"target_id" binding:"required,uuid"`
+	ReasonCode string `json:"reason_code" binding:"required,report_reason"`
+	Details    string `json:"details" binding:"max=2000"`
+}
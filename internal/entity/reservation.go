@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrSlotFull is returned when a reservation doesn't fit in the remaining capacity of its time slot
+var ErrSlotFull = errors.New("reservation slot is full")
+
+// ErrReservationCancelled is returned when a reservation is cancelled while it is being changed
+var ErrReservationCancelled = errors.New("reservation is cancelled")
+
+// Reservation status options
+const (
+	ReservationStatusConfirmed = "confirmed"
+	ReservationStatusCancelled = "cancelled"
+)
+
+// OpeningPeriod is a stretch of a weekday the business is open, in its local time
+type OpeningPeriod struct {
+	Day   int    `json:"day" binding:"min=0,max=6"`      // 0 is Sunday
+	Open  string `json:"open" binding:"required,clock"`  // e.g. "09:00"
+	Close string `json:"close" binding:"required,clock"` // e.g. "22:00", "24:00" is midnight
+}
+
+// OpeningHours is the weekly schedule of a business
+type OpeningHours struct {
+	Timezone string          `json:"timezone" binding:"required,timezone"` // IANA name, e.g. "Asia/Tashkent"
+	Periods  []OpeningPeriod `json:"periods" binding:"max=21,dive"`
+}
+
+// clockMinutes turns "HH:MM" into minutes since midnight.
+func clockMinutes(clock string) int {
+	var hours, minutes int
+	_, _ = fmt.Sscanf(clock, "%d:%d", &hours, &minutes)
+	return hours*60 + minutes
+}
+
+// Check reports why the schedule is invalid, or "" if it is valid.
+func (h OpeningHours) Check() string {
+	for _, period := range h.Periods {
+		if clockMinutes(period.Close) <= clockMinutes(period.Open) {
+			return fmt.Sprintf("periods must close after they open, %s to %s isn't", period.Open, period.Close)
+		}
+	}
+	return ""
+}
+
+// Slots returns the start times of the reservation slots on a date ("2006-01-02") in the business timezone.
+// A slot starts every slotMinutes from opening and must end by closing time.
+func (h OpeningHours) Slots(date string, slotMinutes int) ([]time.Time, error) {
+	loc, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []time.Time
+	for _, period := range h.Periods {
+		if time.Weekday(period.Day) != day.Weekday() || slotMinutes <= 0 {
+			continue
+		}
+
+		for m := clockMinutes(period.Open); m+slotMinutes <= clockMinutes(period.Close); m += slotMinutes {
+			slots = append(slots, time.Date(day.Year(), day.Month(), day.Day(), m/60, m%60, 0, 0, loc))
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Before(slots[j]) })
+
+	return slots, nil
+}
+
+// IsSlot reports whether t is the start of a reservation slot.
+func (h OpeningHours) IsSlot(t time.Time, slotMinutes int) bool {
+	loc, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		return false
+	}
+
+	slots, err := h.Slots(t.In(loc).Format("2006-01-02"), slotMinutes)
+	if err != nil {
+		return false
+	}
+
+	for _, slot := range slots {
+		if slot.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReservationSettings is how a business takes reservations
+type ReservationSettings struct {
+	BusinessID       string `json:"business_id"`
+	Enabled          bool   `json:"enabled"`
+	SlotMinutes      int    `json:"slot_minutes" binding:"min=15,max=240"`
+	Capacity         int    `json:"capacity" binding:"min=1,max=1000"` // guests per slot
+	MaxPartySize     int    `json:"max_party_size" binding:"min=1,max=100"`
+	MinNoticeMinutes int    `json:"min_notice_minutes" binding:"min=0,max=10080"`
+	MaxDaysAhead     int    `json:"max_days_ahead" binding:"min=1,max=365"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+// DefaultReservationSettings are the settings of a business that never set them up, reservations are off.
+func DefaultReservationSettings(businessID string) ReservationSettings {
+	return ReservationSettings{
+		BusinessID:       businessID,
+		SlotMinutes:      30,
+		Capacity:         20,
+		MaxPartySize:     8,
+		MinNoticeMinutes: 60,
+		MaxDaysAhead:     60,
+	}
+}
+
+// Reservation is a table or appointment booked by a user. StartsAt is the start of a slot, in UTC.
+type Reservation struct {
+	ID           string `json:"id"`
+	BusinessID   string `json:"business_id"`
+	BusinessName string `json:"business_name"`
+	UserID       string `json:"user_id"`
+	PartySize    int    `json:"party_size"`
+	StartsAt     string `json:"starts_at"`
+	Status       string `json:"status"`
+	Note         string `json:"note"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type ReservationList struct {
+	Items []Reservation `json:"reservations"`
+	Count int           `json:"count"`
+}
+
+type CreateReservationRequest struct {
+	BusinessID string `json:"business_id" binding:"required,uuid"`
+	StartsAt   string `json:"starts_at" binding:"required"` // RFC 3339, e.g. "2024-05-01T19:30:00+05:00"
+	PartySize  int    `json:"party_size" binding:"required,min=1"`
+	Note       string `json:"note" binding:"max=500"`
+}
+
+type RescheduleReservationRequest struct {
+	ID        string `json:"id" binding:"required,uuid"`
+	StartsAt  string `json:"starts_at" binding:"required"`
+	PartySize int    `json:"party_size" binding:"required,min=1"`
+}
+
+// ReservationSlot is a time slot with the number of guests it still has room for
+type ReservationSlot struct {
+	StartsAt  string `json:"starts_at"`
+	Booked    int    `json:"booked"`
+	Available int    `json:"available"`
+}
+
+// ReservationAvailability is the slots of a business on a date that can still be booked
+type ReservationAvailability struct {
+	BusinessID string            `json:"business_id"`
+	Date       string            `json:"date"`
+	Timezone   string            `json:"timezone"`
+	Slots      []ReservationSlot `json:"slots"`
+}
+
+type ReservationCalendarDay struct {
+	Date         string            `json:"date"`
+	Slots        []ReservationSlot `json:"slots"`
+	Reservations []Reservation     `json:"reservations"`
+}
+
+// ReservationCalendar is the bookings of a business by day, for its owner
+type ReservationCalendar struct {
+	BusinessID string                   `json:"business_id"`
+	Timezone   string                   `json:"timezone"`
+	Days       []ReservationCalendarDay `json:"days"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpeningHours_Slots(t *testing.T) {
+	hours := OpeningHours{
+		Timezone: "Asia/Tashkent",
+		Periods: []OpeningPeriod{
+			{Day: 3, Open: "18:00", Close: "20:00"},
+			{Day: 3, Open: "12:00", Close: "13:00"},
+			{Day: 4, Open: "09:00", Close: "24:00"},
+		},
+	}
+
+	// 2024-05-01 is a Wednesday
+	slots, err := hours.Slots("2024-05-01", 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, slot := range slots {
+		got = append(got, slot.UTC().Format(time.RFC3339))
+	}
+
+	expected := []string{"2024-05-01T07:00:00Z", "2024-05-01T13:00:00Z", "2024-05-01T14:00:00Z"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+
+	slot, _ := time.Parse(time.RFC3339, "2024-05-01T18:00:00+05:00")
+	if !hours.IsSlot(slot, 60) {
+		t.Fatal("expected 18:00 local time to be a slot")
+	}
+	if hours.IsSlot(slot.Add(30*time.Minute), 60) || hours.IsSlot(slot.Add(2*time.Hour), 60) {
+		t.Fatal("expected times between slots and after closing not to be slots")
+	}
+
+	if slots, _ := hours.Slots("2024-05-02", 90); len(slots) != 10 || slots[9].Format("15:04") != "22:30" {
+		t.Fatalf("expected slots until 22:30 on Thursday, got %v", slots)
+	}
+}
+
+func TestOpeningHours_Check(t *testing.T) {
+	if msg := (OpeningHours{Periods: []OpeningPeriod{{Open: "09:00", Close: "24:00"}}}).Check(); msg != "" {
+		t.Fatalf("expected a valid schedule, got %q", msg)
+	}
+	if msg := (OpeningHours{Periods: []OpeningPeriod{{Open: "22:00", Close: "02:00"}}}).Check(); msg == "" {
+		t.Fatal("expected a period closing before it opens to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package entity
+
+// Review vote types, Yelp-style
+const (
+	ReviewVoteUseful = "useful"
+	ReviewVoteFunny  = "funny"
+	ReviewVoteCool   = "cool"
+)
+
+// Review sort orders accepted by the review list
+const (
+	ReviewSortNewest      = "newest"
+	ReviewSortMostHelpful = "most_helpful"
+	ReviewSortRatingHigh  = "rating_high"
+	ReviewSortRatingLow   = "rating_low"
+)
+
+type Review struct {
+	ID          string       `json:"id"`
+	BusinessID  string       `json:"business_id"`
+	UserID      string       `json:"user_id"`
+	Rating      int          `json:"rating"`
+	Text        string       `json:"text"`
+	Attachments []string     `json:"attachments"`
+	Status      string       `json:"status"`
+	UsefulCount int          `json:"useful_count"`
+	FunnyCount  int          `json:"funny_count"`
+	CoolCount   int          `json:"cool_count"`
+	Reply       *ReviewReply `json:"reply,omitempty"`
+	MyVotes     []string     `json:"my_votes,omitempty"` // votes of the current principal
+	TextHash    string       `json:"-"`                  // contentfilter.Fingerprint of Text
+	CreatedAt   string       `json:"created_at"`
+	UpdatedAt   string       `json:"updated_at"`
+}
+
+type ReviewList struct {
+	Items []Review `json:"reviews"`
+	Count int      `json:"count"`
+}
+
+type CreateReviewRequest struct {
+	BusinessID  string   `json:"business_id" binding:"required,uuid"`
+	Rating      int      `json:"rating" binding:"required,min=1,max=5"`
+	Text        string   `json:"text" binding:"required,max=5000"`
+	Attachments []string `json:"attachments" binding:"max=10"`
+}
+
+type UpdateReviewRequest struct {
+	ID          string   `json:"id" binding:"required,uuid"`
+	Rating      int      `json:"rating" binding:"required,min=1,max=5"`
+	Text        string   `json:"text" binding:"required,max=5000"`
+	Attachments []string `json:"attachments" binding:"max=10"`
+}
+
+// ReviewReply is the business owner's public answer to a review
+type ReviewReply struct {
+	ID        string `json:"id"`
+	ReviewID  string `json:"review_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ReviewReplySingleRequest looks a reply up by its own ID or by the ID of the review it answers
+type ReviewReplySingleRequest struct {
+	ID       string `json:"id"`
+	ReviewID string `json:"review_id"`
+}
+
+type ReviewReplyRequest struct {
+	Text string `json:"text" binding:"required,max=5000"`
+}
+
+// ReviewVote is a single user's useful, funny or cool vote on a review
+type ReviewVote struct {
+	ReviewID string `json:"review_id"`
+	UserID   string `json:"user_id"`
+	VoteType string `json:"vote_type"`
+}
+
+type ReviewVoteRequest struct {
+	VoteType string `json:"vote_type" binding:"required,review_vote"`
+}
+
+// ReviewVoteResponse tells whether the vote is now set, along with the updated counters
+type ReviewVoteResponse struct {
+	VoteType    string `json:"vote_type"`
+	Voted       bool   `json:"voted"`
+	UsefulCount int    `json:"useful_count"`
+	FunnyCount  int    `json:"funny_count"`
+	CoolCount   int    `json:"cool_count"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+// Sources of a business revision
+const (
+	RevisionSourceCreate     = "create"
+	RevisionSourceUpdate     = "update"
+	RevisionSourceAttributes = "attributes"
+	RevisionSourceHours      = "hours"
+	RevisionSourceSuggestion = "suggestion" // an approved EditSuggestion, authored by the user who made it
+	RevisionSourceRollback   = "rollback"
+)
+
+// BusinessSnapshot is the content of a business as of one revision
+type BusinessSnapshot struct {
+	Name               string                 `json:"name"`
+	Location           Location               `json:"location"`
+	CategoryIDs        []string               `json:"category_ids"`
+	Description        string                 `json:"description"`
+	ContactInformation string                 `json:"contact_information"`
+	Attachments        []string               `json:"attachments"`
+	PriceLevel         int                    `json:"price_level"`
+	Attributes         map[string]interface{} `json:"attributes"`
+	OpeningHours       OpeningHours           `json:"opening_hours"`
+	ClosedPermanently  bool                   `json:"closed_permanently"`
+}
+
+// BusinessRevision is written every time the content of a business changes. Version 1 is the business as created.
+type BusinessRevision struct {
+	ID         string           `json:"id"`
+	BusinessID string           `json:"business_id"`
+	Version    int              `json:"version"`
+	Snapshot   BusinessSnapshot `json:"snapshot"`
+	AuthorID   string           `json:"author_id"` // empty once the author is deleted
+	Source     string           `json:"source"`
+	CreatedAt  string           `json:"created_at"`
+}
+
+type BusinessRevisionList struct {
+	Items []BusinessRevision `json:"revisions"`
+	Count int                `json:"count"`
+}
+
+// BusinessRevisionDiff holds the fields that changed between two revisions of a business
+type BusinessRevisionDiff struct {
+	BusinessID string                 `json:"business_id"`
+	From       int                    `json:"from"`
+	To         int                    `json:"to"`
+	Changes    map[string]FieldChange `json:"changes"`
+}
+
+type RollbackBusinessRequest struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
@@ -1,14 +1,20 @@
 package entity
 
 type Session struct {
-	ID           string `json:"id"`
+	ID           string `json:"id" binding:"required,uuid"`
 	UserID       string `json:"user_id"`
 	IPAddress    string `json:"ip_address"`
 	UserAgent    string `json:"user_agent"`
 	IsActive     bool   `json:"is_active"`
 	ExpiresAt    string `json:"expires_at"`
 	LastActiveAt string `json:"last_active_at"`
-	Platform     string `json:"platform"`
+	Platform     string `json:"platform" binding:"omitempty,platform"`
+	Browser      string `json:"browser"`
+	OS           string `json:"os"`
+	DeviceType   string `json:"device_type"`
+	Country      string `json:"country"`
+	City         string `json:"city"`
+	IsCurrent    bool   `json:"is_current"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
 }
@@ -0,0 +1,84 @@
+package entity
+
+import "errors"
+
+// ErrSuggestionReviewed is returned when approving or rejecting a suggestion that is no longer pending
+var ErrSuggestionReviewed = errors.New("edit suggestion was already reviewed")
+
+// Edit suggestion statuses
+const (
+	SuggestionStatusPending  = "pending"
+	SuggestionStatusApproved = "approved"
+	SuggestionStatusRejected = "rejected"
+)
+
+// BusinessEdit holds the fields of a business anyone can suggest a correction to. Fields left out stay as they are.
+type BusinessEdit struct {
+	Name               *string       `json:"name,omitempty" binding:"omitempty,min=1,max=255"`
+	Location           *Location     `json:"location,omitempty"`
+	ContactInformation *string       `json:"contact_information,omitempty" binding:"omitempty,max=1000"`
+	OpeningHours       *OpeningHours `json:"opening_hours,omitempty"`
+	ClosedPermanently  *bool         `json:"closed_permanently,omitempty"`
+}
+
+// EditOf returns every editable field of the business.
+func EditOf(business Business) BusinessEdit {
+	return BusinessEdit{
+		Name:               &business.Name,
+		Location:           &business.Location,
+		ContactInformation: &business.ContactInformation,
+		OpeningHours:       &business.OpeningHours,
+		ClosedPermanently:  &business.ClosedPermanently,
+	}
+}
+
+// Apply returns the business with the fields set in the edit replaced.
+func (e BusinessEdit) Apply(business Business) Business {
+	if e.Name != nil {
+		business.Name = *e.Name
+	}
+	if e.Location != nil {
+		business.Location = *e.Location
+	}
+	if e.ContactInformation != nil {
+		business.ContactInformation = *e.ContactInformation
+	}
+	if e.OpeningHours != nil {
+		business.OpeningHours = *e.OpeningHours
+	}
+	if e.ClosedPermanently != nil {
+		business.ClosedPermanently = *e.ClosedPermanently
+	}
+	return business
+}
+
+// EditSuggestion is a correction to a business proposed by a user, applied once the owner or an admin approves it
+type EditSuggestion struct {
+	ID           string                 `json:"id"`
+	BusinessID   string                 `json:"business_id"`
+	BusinessName string                 `json:"business_name"`
+	UserID       string                 `json:"user_id"`
+	Edit         BusinessEdit           `json:"edit"`
+	Changes      map[string]FieldChange `json:"changes"` // against the business when it was suggested
+	Note         string                 `json:"note"`
+	Status       string                 `json:"status"`
+	ReviewedBy   string                 `json:"reviewed_by,omitempty"`
+	ReviewNote   string                 `json:"review_note,omitempty"`
+	ReviewedAt   string                 `json:"reviewed_at,omitempty"`
+	CreatedAt    string                 `json:"created_at"`
+	UpdatedAt    string                 `json:"updated_at"`
+}
+
+type EditSuggestionList struct {
+	Items []EditSuggestion `json:"suggestions"`
+	Count int              `json:"count"`
+}
+
+type CreateEditSuggestionRequest struct {
+	Edit BusinessEdit `json:"edit" binding:"required"`
+	Note string       `json:"note" binding:"max=1000"` // why the business should change, e.g. "they moved across the street"
+}
+
+type ReviewEditSuggestionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
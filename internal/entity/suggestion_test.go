@@ -0,0 +1,36 @@
+package entity
+
+import "testing"
+
+func TestBusinessEditApply(t *testing.T) {
+	business := Business{
+		Name:               "Old name",
+		Location:           Location{Latitude: 41.31, Longitude: 69.24},
+		ContactInformation: "+998 90 000 00 00",
+	}
+
+	name := "New name"
+	closed := true
+	got := BusinessEdit{Name: &name, ClosedPermanently: &closed}.Apply(business)
+
+	if got.Name != name || !got.ClosedPermanently {
+		t.Errorf("Apply didn't set the edited fields: %+v", got)
+	}
+
+	if got.Location != business.Location || got.ContactInformation != business.ContactInformation {
+		t.Errorf("Apply changed fields left out of the edit: %+v", got)
+	}
+
+	if business.Name != "Old name" {
+		t.Errorf("Apply changed the original business")
+	}
+}
+
+func TestEditOfApply(t *testing.T) {
+	business := Business{Name: "Cafe", OpeningHours: OpeningHours{Timezone: "UTC", Periods: []OpeningPeriod{}}}
+
+	got := EditOf(business).Apply(Business{})
+	if got.Name != business.Name || got.OpeningHours.Timezone != business.OpeningHours.Timezone {
+		t.Errorf("EditOf(b).Apply() = %+v, want the fields of %+v", got, business)
+	}
+}
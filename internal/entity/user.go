@@ -22,12 +22,63 @@ type User struct {
 	UserType    string `json:"user_type"`
 	UserRole    string `json:"user_role"`
 	Status      string `json:"status"`
+	BlockReason string `json:"block_reason,omitempty"`
 	AccessToken string `json:"access_token"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
 	DeletedAt   string `json:"deleted_at,omitempty"` // can be null
 }
 
+// UserResponse is the public representation of a user. It never carries the password hash.
+type UserResponse struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	FullName    string `json:"full_name"`
+	UserType    string `json:"user_type"`
+	UserRole    string `json:"user_role"`
+	Status      string `json:"status"`
+	BlockReason string `json:"block_reason,omitempty"`
+	AccessToken string `json:"access_token,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ToResponse maps a user to its public representation
+func (u User) ToResponse() UserResponse {
+	return UserResponse{
+		ID:          u.ID,
+		Email:       u.Email,
+		FullName:    u.FullName,
+		UserType:    u.UserType,
+		UserRole:    u.UserRole,
+		Status:      u.Status,
+		BlockReason: u.BlockReason,
+		AccessToken: u.AccessToken,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
+
+// CreateUserRequest is used by admins to create a user directly
+type CreateUserRequest struct {
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,password"`
+	FullName string `json:"full_name" binding:"max=255"`
+	UserRole string `json:"user_role" binding:"omitempty,user_role"`
+	Status   string `json:"status" binding:"omitempty,user_status"`
+}
+
+// UpdateUserRequest is a partial update, empty fields are left unchanged.
+// UserRole and Status can only be changed by admins.
+type UpdateUserRequest struct {
+	ID       string `json:"id" binding:"omitempty,uuid"`
+	Email    string `json:"email" binding:"omitempty,email,max=255"`
+	Password string `json:"password" binding:"omitempty,password"`
+	FullName string `json:"full_name" binding:"max=255"`
+	UserRole string `json:"user_role" binding:"omitempty,user_role"`
+	Status   string `json:"status" binding:"omitempty,user_status"`
+}
+
 type UserSingleRequest struct {
 	ID    string `json:"id"`
 	Email string `json:"email"`
@@ -37,3 +88,30 @@ type UserList struct {
 	Items []User `json:"users"`
 	Count int    `json:"count"`
 }
+
+type UserListResponse struct {
+	Items []UserResponse `json:"users"`
+	Count int            `json:"count"`
+}
+
+// ToResponse maps every user of the list to its public representation
+func (l UserList) ToResponse() UserListResponse {
+	response := UserListResponse{
+		Items: make([]UserResponse, 0, len(l.Items)),
+		Count: l.Count,
+	}
+
+	for _, user := range l.Items {
+		response.Items = append(response.Items, user.ToResponse())
+	}
+
+	return response
+}
+
+type BlockUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type UpdateUserRoleRequest struct {
+	UserRole string `json:"user_role" binding:"required,user_role"`
+}
@@ -4,6 +4,8 @@ package usecase
 import (
 	"context"
 
+	"github.com/casbin/casbin/persist"
+
 	"yalp_ulab/internal/entity"
 )
 
@@ -28,6 +30,7 @@ type (
 		Update(ctx context.Context, req entity.Session) (entity.Session, error)
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+		RememberDevice(ctx context.Context, req entity.Session) (bool, error)
 	}
 
 	// BusinessRepo -.
@@ -38,5 +41,211 @@ type (
 		Update(ctx context.Context, req entity.Business) (entity.Business, error)
 		Delete(ctx context.Context, req entity.Id) error
 		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+		SetAttributes(ctx context.Context, id string, attributes map[string]interface{}, authorID string) error
+		SetOpeningHours(ctx context.Context, id string, hours entity.OpeningHours, authorID string) error
+		Rollback(ctx context.Context, id string, snapshot entity.BusinessSnapshot, textHash, authorID string) error
+		FindSimilar(ctx context.Context, name string, southWest, northEast entity.Location, minSimilarity float64, limit int) ([]entity.DuplicateCandidate, error)
+		Merge(ctx context.Context, sourceID, targetID string) (entity.MergeBusinessResult, error)
+	}
+
+	// BusinessRevisionRepo -.
+	BusinessRevisionRepoI interface {
+		GetSingle(ctx context.Context, businessID string, version int) (entity.BusinessRevision, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessRevisionList, error)
+	}
+
+	// CategoryRepo -.
+	CategoryRepoI interface {
+		Create(ctx context.Context, req entity.Category) (entity.Category, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Category, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.CategoryList, error)
+		Update(ctx context.Context, req entity.Category) (entity.Category, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// AttributeRepo -.
+	AttributeRepoI interface {
+		Create(ctx context.Context, req entity.Attribute) (entity.Attribute, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Attribute, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.AttributeList, error)
+		Update(ctx context.Context, req entity.Attribute) (entity.Attribute, error)
+		Delete(ctx context.Context, req entity.Attribute) error
+	}
+
+	// ReviewRepo -.
+	ReviewRepoI interface {
+		Create(ctx context.Context, req entity.Review) (entity.Review, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Review, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewList, error)
+		Update(ctx context.Context, req entity.Review) (entity.Review, error)
+		Delete(ctx context.Context, req entity.Id) error
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+	}
+
+	// ReviewReplyRepo -.
+	ReviewReplyRepoI interface {
+		Create(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error)
+		GetSingle(ctx context.Context, req entity.ReviewReplySingleRequest) (entity.ReviewReply, error)
+		Update(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// ReviewVoteRepo -.
+	ReviewVoteRepoI interface {
+		Create(ctx context.Context, req entity.ReviewVote) error
+		Delete(ctx context.Context, req entity.ReviewVote) (entity.RowsEffected, error)
+		GetList(ctx context.Context, userID string, reviewIDs []string) ([]entity.ReviewVote, error)
+	}
+
+	// ReportRepo -.
+	ReportRepoI interface {
+		Create(ctx context.Context, req entity.Report) (entity.Report, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Report, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReportList, error)
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+	}
+
+	// PhotoRepo -.
+	PhotoRepoI interface {
+		Create(ctx context.Context, req entity.Photo) (entity.Photo, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Photo, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.PhotoList, error)
+		Delete(ctx context.Context, req entity.Id) error
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+	}
+
+	// CollectionRepo -.
+	CollectionRepoI interface {
+		Create(ctx context.Context, req entity.Collection) (entity.Collection, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Collection, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.CollectionList, error)
+		Update(ctx context.Context, req entity.Collection) (entity.Collection, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// CollectionItemRepo -.
+	CollectionItemRepoI interface {
+		Create(ctx context.Context, req entity.CollectionItem) (entity.CollectionItem, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.CollectionItemList, error)
+		Delete(ctx context.Context, req entity.CollectionItem) (entity.RowsEffected, error)
+		GetBookmarked(ctx context.Context, userID string, businessIDs []string) ([]string, error)
+	}
+
+	// MenuRepo -.
+	MenuRepoI interface {
+		Create(ctx context.Context, req entity.Menu) (entity.Menu, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Menu, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.MenuList, error)
+		Update(ctx context.Context, req entity.Menu) (entity.Menu, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// QuestionRepo -.
+	QuestionRepoI interface {
+		Create(ctx context.Context, req entity.Question) (entity.Question, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Question, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.QuestionList, error)
+		SetAcceptedAnswer(ctx context.Context, questionID, answerID string) error
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// AnswerRepo -.
+	AnswerRepoI interface {
+		Create(ctx context.Context, req entity.Answer) (entity.Answer, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Answer, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.AnswerList, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// UpvoteRepo -.
+	UpvoteRepoI interface {
+		Create(ctx context.Context, req entity.Upvote) error
+		Delete(ctx context.Context, req entity.Upvote) (entity.RowsEffected, error)
+		GetList(ctx context.Context, userID, target string, targetIDs []string) ([]entity.Upvote, error)
+	}
+
+	// EditSuggestionRepo -.
+	EditSuggestionRepoI interface {
+		Create(ctx context.Context, req entity.EditSuggestion) (entity.EditSuggestion, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.EditSuggestion, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.EditSuggestionList, error)
+		Approve(ctx context.Context, req entity.EditSuggestion, reviewerID, note, textHash string) error
+		Reject(ctx context.Context, id, reviewerID, note string) error
+	}
+
+	// PromotionRepo -.
+	PromotionRepoI interface {
+		Create(ctx context.Context, req entity.Promotion) (entity.Promotion, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.Promotion, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.PromotionList, error)
+		Update(ctx context.Context, req entity.Promotion) (entity.Promotion, error)
+		Delete(ctx context.Context, req entity.Id) error
+	}
+
+	// ReservationSettingsRepo -.
+	ReservationSettingsRepoI interface {
+		GetSingle(ctx context.Context, req entity.Id) (entity.ReservationSettings, error)
+		Upsert(ctx context.Context, req entity.ReservationSettings) (entity.ReservationSettings, error)
+	}
+
+	// ReservationRepo -.
+	ReservationRepoI interface {
+		Create(ctx context.Context, req entity.Reservation, capacity int) (entity.Reservation, error)
+		Reschedule(ctx context.Context, req entity.Reservation, capacity int) error
+		GetSingle(ctx context.Context, req entity.Id) (entity.Reservation, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ReservationList, error)
+		CountBooked(ctx context.Context, businessID, from, to string) (map[string]int, error)
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+	}
+
+	// CheckInRepo -.
+	CheckInRepoI interface {
+		Create(ctx context.Context, req entity.CheckIn, dailyLimit int) (entity.CheckIn, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.CheckInList, error)
+	}
+
+	// FollowRepo -.
+	FollowRepoI interface {
+		Create(ctx context.Context, req entity.Follow) error
+		Delete(ctx context.Context, req entity.Follow) (entity.RowsEffected, error)
+		Exists(ctx context.Context, req entity.Follow) (bool, error)
+		GetFollowers(ctx context.Context, req entity.GetListFilter) (entity.FollowUserList, error)
+		GetFollowing(ctx context.Context, req entity.GetListFilter) (entity.FollowUserList, error)
+		GetFeed(ctx context.Context, req entity.FeedRequest) ([]entity.FeedItem, error)
+	}
+
+	// ProfileRepo -.
+	ProfileRepoI interface {
+		GetSingle(ctx context.Context, req entity.Id) (entity.ProfileRecord, error)
+		Update(ctx context.Context, req entity.ProfileRecord) error
+	}
+
+	// ModerationCaseRepo -.
+	ModerationCaseRepoI interface {
+		Open(ctx context.Context, req entity.ModerationCase) (entity.ModerationCase, error)
+		GetSingle(ctx context.Context, req entity.Id) (entity.ModerationCase, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ModerationCaseList, error)
+		UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error)
+		Apply(ctx context.Context, req entity.ModerationStep) error
+	}
+
+	// ModerationHistoryRepo -.
+	ModerationHistoryRepoI interface {
+		Create(ctx context.Context, req entity.ModerationHistory) (entity.ModerationHistory, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.ModerationHistoryList, error)
+	}
+
+	// AuditLogRepo -.
+	AuditLogRepoI interface {
+		Create(ctx context.Context, req entity.AuditLog) (entity.AuditLog, error)
+		GetList(ctx context.Context, req entity.GetListFilter) (entity.AuditLogList, error)
+	}
+
+	// PolicyRepo -.
+	PolicyRepoI interface {
+		persist.Adapter
+		Create(ctx context.Context, req entity.PolicyRule) error
+		GetList(ctx context.Context) ([]entity.PolicyRule, error)
+		Delete(ctx context.Context, req entity.PolicyRule) (entity.RowsEffected, error)
 	}
 )
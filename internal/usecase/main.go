@@ -9,16 +9,66 @@ import (
 
 // UseCase -.
 type UseCase struct {
-	UserRepo     UserRepoI
-	SessionRepo  SessionRepoI
-	BusinessRepo BusinessRepoI
+	UserRepo                UserRepoI
+	SessionRepo             SessionRepoI
+	BusinessRepo            BusinessRepoI
+	ReviewRepo              ReviewRepoI
+	ReviewReplyRepo         ReviewReplyRepoI
+	ReviewVoteRepo          ReviewVoteRepoI
+	ReportRepo              ReportRepoI
+	PhotoRepo               PhotoRepoI
+	CollectionRepo          CollectionRepoI
+	CollectionItemRepo      CollectionItemRepoI
+	CheckInRepo             CheckInRepoI
+	MenuRepo                MenuRepoI
+	AttributeRepo           AttributeRepoI
+	CategoryRepo            CategoryRepoI
+	PromotionRepo           PromotionRepoI
+	BusinessRevisionRepo    BusinessRevisionRepoI
+	EditSuggestionRepo      EditSuggestionRepoI
+	QuestionRepo            QuestionRepoI
+	AnswerRepo              AnswerRepoI
+	UpvoteRepo              UpvoteRepoI
+	ReservationSettingsRepo ReservationSettingsRepoI
+	ReservationRepo         ReservationRepoI
+	FollowRepo              FollowRepoI
+	ProfileRepo             ProfileRepoI
+	ModerationCaseRepo      ModerationCaseRepoI
+	ModerationHistoryRepo   ModerationHistoryRepoI
+	AuditLogRepo            AuditLogRepoI
+	PolicyRepo              PolicyRepoI
 }
 
 // New -.
 func New(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *UseCase {
 	return &UseCase{
-		UserRepo:     repo.NewUserRepo(pg, config, logger),
-		SessionRepo:  repo.NewSessionRepo(pg, config, logger),
-		BusinessRepo: repo.NewBusinessRepo(pg, config, logger),
+		UserRepo:                repo.NewUserRepo(pg, config, logger),
+		SessionRepo:             repo.NewSessionRepo(pg, config, logger),
+		BusinessRepo:            repo.NewBusinessRepo(pg, config, logger),
+		ReviewRepo:              repo.NewReviewRepo(pg, config, logger),
+		ReviewReplyRepo:         repo.NewReviewReplyRepo(pg, config, logger),
+		ReviewVoteRepo:          repo.NewReviewVoteRepo(pg, config, logger),
+		ReportRepo:              repo.NewReportRepo(pg, config, logger),
+		PhotoRepo:               repo.NewPhotoRepo(pg, config, logger),
+		CollectionRepo:          repo.NewCollectionRepo(pg, config, logger),
+		CollectionItemRepo:      repo.NewCollectionItemRepo(pg, config, logger),
+		CheckInRepo:             repo.NewCheckInRepo(pg, config, logger),
+		MenuRepo:                repo.NewMenuRepo(pg, config, logger),
+		AttributeRepo:           repo.NewAttributeRepo(pg, config, logger),
+		CategoryRepo:            repo.NewCategoryRepo(pg, config, logger),
+		PromotionRepo:           repo.NewPromotionRepo(pg, config, logger),
+		BusinessRevisionRepo:    repo.NewBusinessRevisionRepo(pg, config, logger),
+		EditSuggestionRepo:      repo.NewEditSuggestionRepo(pg, config, logger),
+		QuestionRepo:            repo.NewQuestionRepo(pg, config, logger),
+		AnswerRepo:              repo.NewAnswerRepo(pg, config, logger),
+		UpvoteRepo:              repo.NewUpvoteRepo(pg, config, logger),
+		ReservationSettingsRepo: repo.NewReservationSettingsRepo(pg, config, logger),
+		ReservationRepo:         repo.NewReservationRepo(pg, config, logger),
+		FollowRepo:              repo.NewFollowRepo(pg, config, logger),
+		ProfileRepo:             repo.NewProfileRepo(pg, config, logger),
+		ModerationCaseRepo:      repo.NewModerationCaseRepo(pg, config, logger),
+		ModerationHistoryRepo:   repo.NewModerationHistoryRepo(pg, config, logger),
+		AuditLogRepo:            repo.NewAuditLogRepo(pg, config, logger),
+		PolicyRepo:              repo.NewPolicyRepo(pg, config, logger),
 	}
 }
@@ -0,0 +1,192 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const attributeColumns = `id, key, name, description, type, options, unit, created_at, updated_at`
+
+type AttributeRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewAttributeRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *AttributeRepo {
+	return &AttributeRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *AttributeRepo) Create(ctx context.Context, req entity.Attribute) (entity.Attribute, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("attributes").
+		Columns(`id, key, name, description, type, options, unit`).
+		Values(req.ID, req.Key, req.Name, req.Description, req.Type, req.Options, req.Unit).
+		Suffix("RETURNING " + attributeColumns).ToSql()
+	if err != nil {
+		return entity.Attribute{}, err
+	}
+
+	return scanAttribute(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *AttributeRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Attribute, error) {
+	query, args, err := r.pg.Builder.
+		Select(attributeColumns).
+		From("attributes").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Attribute{}, err
+	}
+
+	return scanAttribute(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *AttributeRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.AttributeList, error) {
+	response := entity.AttributeList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(attributeColumns).
+		From("attributes")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanAttribute(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("attributes").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Update changes the attribute. Values of businesses that are no longer among the options of an enum attribute are removed.
+func (r *AttributeRepo) Update(ctx context.Context, req entity.Attribute) (entity.Attribute, error) {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Attribute{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	mp := map[string]interface{}{
+		"name":        req.Name,
+		"description": req.Description,
+		"options":     req.Options,
+		"unit":        req.Unit,
+		"updated_at":  time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("attributes").SetMap(mp).Where("id = ?", req.ID).
+		Suffix("RETURNING " + attributeColumns).ToSql()
+	if err != nil {
+		return entity.Attribute{}, err
+	}
+
+	attribute, err := scanAttribute(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		return entity.Attribute{}, err
+	}
+
+	if attribute.Type == entity.AttributeTypeEnum {
+		query, args, err = r.pg.Builder.Update("businesses").
+			Set("attributes", squirrel.Expr("attributes - ?::text", attribute.Key)).
+			Where("attributes ?? ?::text", attribute.Key).
+			Where("NOT (attributes->>?::text = ANY(?))", attribute.Key, attribute.Options).ToSql()
+		if err != nil {
+			return entity.Attribute{}, err
+		}
+
+		_, err = tx.Exec(ctx, query, args...)
+		if err != nil {
+			return entity.Attribute{}, err
+		}
+	}
+
+	return attribute, tx.Commit(ctx)
+}
+
+// Delete removes the attribute from the catalog and its values from every business.
+func (r *AttributeRepo) Delete(ctx context.Context, req entity.Attribute) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Delete("attributes").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = r.pg.Builder.Update("businesses").
+		Set("attributes", squirrel.Expr("attributes - ?::text", req.Key)).
+		Where("attributes ?? ?::text", req.Key).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanAttribute(row rowScanner) (entity.Attribute, error) {
+	var (
+		item                 entity.Attribute
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.Key, &item.Name, &item.Description, &item.Type, &item.Options, &item.Unit, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Attribute{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
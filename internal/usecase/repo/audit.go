@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+type AuditLogRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewAuditLogRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *AuditLogRepo {
+	return &AuditLogRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *AuditLogRepo) Create(ctx context.Context, req entity.AuditLog) (entity.AuditLog, error) {
+	req.ID = uuid.NewString()
+	if req.Diff == nil {
+		req.Diff = map[string]entity.FieldChange{}
+	}
+
+	query, args, err := r.pg.Builder.Insert("audit_log").
+		Columns(`id, actor_id, session_id, ip_address, action, target_type, target_id, diff`).
+		Values(req.ID, nullString(req.ActorID), nullString(req.SessionID), req.IPAddress, req.Action,
+			req.TargetType, req.TargetID, req.Diff).ToSql()
+	if err != nil {
+		return entity.AuditLog{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.AuditLog{}, err
+	}
+
+	return req, nil
+}
+
+func (r *AuditLogRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.AuditLogList, error) {
+	var (
+		response  = entity.AuditLogList{}
+		createdAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, actor_id, session_id, ip_address, action, target_type, target_id, diff, created_at`).
+		From("audit_log")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item               entity.AuditLog
+			actorID, sessionID sql.NullString
+		)
+		err = rows.Scan(&item.ID, &actorID, &sessionID, &item.IPAddress, &item.Action,
+			&item.TargetType, &item.TargetID, &item.Diff, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.ActorID = actorID.String
+		item.SessionID = sessionID.String
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("audit_log").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
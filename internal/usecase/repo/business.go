@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"time"
 	"yalp_ulab/config"
@@ -11,6 +12,12 @@ import (
 	"yalp_ulab/pkg/postgres"
 )
 
+// categorySlugs selects the slugs of the categories of a business, in the order they were given.
+func categorySlugs(table string) string {
+	return fmt.Sprintf(`ARRAY(SELECT c.slug FROM categories c WHERE c.id = ANY(%[1]s.category_ids)
+		ORDER BY array_position(%[1]s.category_ids, c.id))`, table)
+}
+
 type BusinessRepo struct {
 	pg     *postgres.Postgres
 	cfg    *config.Config
@@ -25,21 +32,61 @@ func NewBusinessRepo(pg *postgres.Postgres, cfg *config.Config, logger *logger.L
 	}
 }
 
+// businessContent maps the columns Create, Update and Rollback write from the request.
+func businessContent(req entity.Business) map[string]interface{} {
+	// pgx writes a nil slice as NULL, both arrays are NOT NULL
+	if req.Attachments == nil {
+		req.Attachments = []string{}
+	}
+	if req.CategoryIDs == nil {
+		req.CategoryIDs = []string{}
+	}
+
+	return map[string]interface{}{
+		"business_name":       req.Name,
+		"location":            req.Location,
+		"category_ids":        req.CategoryIDs,
+		"description":         req.Description,
+		"contact_information": req.ContactInformation,
+		"attachments":         req.Attachments,
+		"price_level":         req.PriceLevel,
+		"text_hash":           req.TextHash,
+	}
+}
+
 func (r *BusinessRepo) Create(ctx context.Context, req entity.Business) (entity.Business, error) {
 	req.ID = uuid.NewString()
-	query, args, err := r.pg.Builder.Insert("businesses").
-		Columns(`id, business_name, location, category, description, contact_information, attachments, created_by`).
-		Values(req.ID, req.Name, req.Location, req.Category, req.Description, req.ContactInformation, req.Attachments, req.CreatedBy).ToSql()
+	if req.Attachments == nil {
+		req.Attachments = []string{}
+	}
+
+	mp := businessContent(req)
+	mp["id"] = req.ID
+	mp["status"] = req.Status
+	mp["created_by"] = req.CreatedBy
+
+	query, args, err := r.pg.Builder.Insert("businesses").SetMap(mp).ToSql()
 	if err != nil {
 		return entity.Business{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Business{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	err = recordRevision(ctx, r.pg, tx, req.ID, req.CreatedBy, entity.RevisionSourceCreate)
 	if err != nil {
 		return entity.Business{}, err
 	}
 
-	return req, nil
+	return req, tx.Commit(ctx)
 }
 
 func (r *BusinessRepo) GetSingle(ctx context.Context, req entity.BusinessSingleRequest) (entity.Business, error) {
@@ -49,7 +96,8 @@ func (r *BusinessRepo) GetSingle(ctx context.Context, req entity.BusinessSingleR
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, business_name, location, category, description, contact_information, attachments, created_by, created_at, updated_at`).
+		Select(`id, business_name, location, ` + categorySlugs("businesses") + `, description, contact_information, attachments, price_level, attributes,
+			opening_hours, status, COALESCE(merged_into::text, ''), closed_permanently, checkin_count, created_by, created_at, updated_at`).
 		From("businesses")
 
 	switch {
@@ -65,8 +113,8 @@ func (r *BusinessRepo) GetSingle(ctx context.Context, req entity.BusinessSingleR
 	}
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
-		Scan(&response.ID, &response.Name, &response.Location, &response.Category, &response.Description, &response.ContactInformation, &response.Attachments,
-			&response.CreatedBy, &createdAt, &updatedAt)
+		Scan(&response.ID, &response.Name, &response.Location, &response.Categories, &response.Description, &response.ContactInformation, &response.Attachments,
+			&response.PriceLevel, &response.Attributes, &response.OpeningHours, &response.Status, &response.MergedInto, &response.ClosedPermanently, &response.CheckInCount, &response.CreatedBy, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.Business{}, err
 	}
@@ -84,7 +132,8 @@ func (r *BusinessRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, business_name, location, category, description, contact_information, attachments, created_by, created_at, updated_at`).
+		Select(`id, business_name, location, ` + categorySlugs("businesses") + `, description, contact_information, attachments, price_level, attributes,
+			opening_hours, status, COALESCE(merged_into::text, ''), closed_permanently, checkin_count, created_by, created_at, updated_at`).
 		From("businesses")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -102,8 +151,8 @@ func (r *BusinessRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 
 	for rows.Next() {
 		var item entity.Business
-		err = rows.Scan(&item.ID, &item.Name, &item.Location, &item.Category, &item.Description, &item.ContactInformation, &item.Attachments,
-			&item.CreatedBy, &createdAt, &updatedAt)
+		err = rows.Scan(&item.ID, &item.Name, &item.Location, &item.Categories, &item.Description, &item.ContactInformation, &item.Attachments,
+			&item.PriceLevel, &item.Attributes, &item.OpeningHours, &item.Status, &item.MergedInto, &item.ClosedPermanently, &item.CheckInCount, &item.CreatedBy, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -128,27 +177,31 @@ func (r *BusinessRepo) GetList(ctx context.Context, req entity.GetListFilter) (e
 }
 
 func (r *BusinessRepo) Update(ctx context.Context, req entity.Business) (entity.Business, error) {
-	mp := map[string]interface{}{
-		"business_name":       req.Name,
-		"location":            req.Location,
-		"category":            req.Category,
-		"description":         req.Description,
-		"contact_information": req.ContactInformation,
-		"attachments":         req.Attachments,
-		"updated_at":          time.Now().Format(time.RFC3339),
-	}
+	mp := businessContent(req)
+	mp["updated_at"] = time.Now().Format(time.RFC3339)
 
 	query, args, err := r.pg.Builder.Update("businesses").SetMap(mp).Where("id = ?", req.ID).ToSql()
 	if err != nil {
 		return entity.Business{}, err
 	}
 
-	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Business{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query, args...)
 	if err != nil {
 		return entity.Business{}, err
 	}
 
-	return req, nil
+	err = recordRevision(ctx, r.pg, tx, req.ID, req.UpdatedBy, entity.RevisionSourceUpdate)
+	if err != nil {
+		return entity.Business{}, err
+	}
+
+	return req, tx.Commit(ctx)
 }
 
 func (r *BusinessRepo) Delete(ctx context.Context, req entity.Id) error {
@@ -187,3 +240,188 @@ func (r *BusinessRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRe
 
 	return response, nil
 }
+
+// revise updates the columns of a business and records the result as a revision by authorID.
+func (r *BusinessRepo) revise(ctx context.Context, id string, mp map[string]interface{}, authorID, source string) error {
+	mp["updated_at"] = time.Now().Format(time.RFC3339)
+
+	query, args, err := r.pg.Builder.Update("businesses").SetMap(mp).Where("id = ?", id).ToSql()
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = recordRevision(ctx, r.pg, tx, id, authorID, source)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetAttributes replaces all attribute values of a business.
+func (r *BusinessRepo) SetAttributes(ctx context.Context, id string, attributes map[string]interface{}, authorID string) error {
+	return r.revise(ctx, id, map[string]interface{}{"attributes": attributes}, authorID, entity.RevisionSourceAttributes)
+}
+
+// SetOpeningHours replaces the weekly schedule of a business.
+func (r *BusinessRepo) SetOpeningHours(ctx context.Context, id string, hours entity.OpeningHours, authorID string) error {
+	return r.revise(ctx, id, map[string]interface{}{"opening_hours": hours}, authorID, entity.RevisionSourceHours)
+}
+
+// Rollback restores the content of a business to the snapshot of an earlier revision, recorded as a new revision.
+// textHash is the contentfilter.Fingerprint of the restored name and description.
+func (r *BusinessRepo) Rollback(ctx context.Context, id string, snapshot entity.BusinessSnapshot, textHash, authorID string) error {
+	return r.revise(ctx, id, rollbackContent(snapshot, textHash), authorID, entity.RevisionSourceRollback)
+}
+
+// rollbackContent returns the columns a rollback restores from a snapshot. Status, ownership and merges are not content
+// and stay as they are.
+func rollbackContent(snapshot entity.BusinessSnapshot, textHash string) map[string]interface{} {
+	mp := businessContent(entity.Business{
+		Name:               snapshot.Name,
+		Location:           snapshot.Location,
+		CategoryIDs:        snapshot.CategoryIDs,
+		Description:        snapshot.Description,
+		ContactInformation: snapshot.ContactInformation,
+		Attachments:        snapshot.Attachments,
+		PriceLevel:         snapshot.PriceLevel,
+		TextHash:           textHash,
+	})
+	mp["attributes"] = snapshot.Attributes
+	mp["opening_hours"] = snapshot.OpeningHours
+	mp["closed_permanently"] = snapshot.ClosedPermanently
+
+	return mp
+}
+
+// FindSimilar returns published businesses inside the bounding box whose name is at least minSimilarity
+// alike the given one by trigram similarity, most similar first. Merged businesses are left out.
+func (r *BusinessRepo) FindSimilar(ctx context.Context, name string, southWest, northEast entity.Location,
+	minSimilarity float64, limit int) ([]entity.DuplicateCandidate, error) {
+	var response []entity.DuplicateCandidate
+
+	query, args, err := r.pg.Builder.
+		Select("id, business_name, location").
+		Column(squirrel.Expr("similarity(business_name, ?) AS score", name)).
+		From("businesses").
+		Where("status = ? AND merged_into IS NULL", entity.ContentStatusPublished).
+		Where("(location->>'latitude')::float8 BETWEEN ? AND ?", southWest.Latitude, northEast.Latitude).
+		Where("(location->>'longitude')::float8 BETWEEN ? AND ?", southWest.Longitude, northEast.Longitude).
+		Where("similarity(business_name, ?) >= ?", name, minSimilarity).
+		OrderBy("score DESC").
+		Limit(uint64(limit)).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.DuplicateCandidate
+		err = rows.Scan(&item.ID, &item.Name, &item.Location, &item.Similarity)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// Merge moves the reviews, photos, bookmarks, check-ins and questions of the source business to the target
+// and hides the source, leaving merged_into pointing at the target. Reviews and bookmarks the target
+// already has from the same user or collection are dropped, the target's own are kept.
+func (r *BusinessRepo) Merge(ctx context.Context, sourceID, targetID string) (entity.MergeBusinessResult, error) {
+	response := entity.MergeBusinessResult{SourceID: sourceID, TargetID: targetID}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return response, err
+	}
+	defer tx.Rollback(ctx)
+
+	exec := func(b squirrel.Sqlizer) (int, error) {
+		query, args, err := b.ToSql()
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return int(n.RowsAffected()), nil
+	}
+
+	steps := []struct {
+		query squirrel.Sqlizer
+		count *int
+	}{
+		{query: r.pg.Builder.Delete("reviews").
+			Where("business_id = ? AND user_id IN (SELECT user_id FROM reviews WHERE business_id = ?)", sourceID, targetID),
+			count: &response.DroppedReviews},
+		{query: r.pg.Builder.Update("reviews").Set("business_id", targetID).Where("business_id = ?", sourceID),
+			count: &response.Reviews},
+		{query: r.pg.Builder.Update("photos").Set("business_id", targetID).Where("business_id = ?", sourceID),
+			count: &response.Photos},
+		{query: r.pg.Builder.Delete("collection_items").
+			Where("business_id = ? AND collection_id IN (SELECT collection_id FROM collection_items WHERE business_id = ?)", sourceID, targetID),
+			count: &response.DroppedBookmarks},
+		{query: r.pg.Builder.Update("collection_items").Set("business_id", targetID).Where("business_id = ?", sourceID),
+			count: &response.Bookmarks},
+		{query: r.pg.Builder.Update("checkins").Set("business_id", targetID).Where("business_id = ?", sourceID),
+			count: &response.CheckIns},
+		{query: r.pg.Builder.Update("questions").Set("business_id", targetID).Where("business_id = ?", sourceID),
+			count: &response.Questions},
+		// businesses merged into the source earlier now redirect straight to the target
+		{query: r.pg.Builder.Update("businesses").Set("merged_into", targetID).Where("merged_into = ?", sourceID)},
+	}
+
+	for _, step := range steps {
+		n, err := exec(step.query)
+		if err != nil {
+			return response, err
+		}
+
+		if step.count != nil {
+			*step.count = n
+		}
+	}
+
+	// checkin_count is only maintained by triggers on insert and delete
+	_, err = exec(r.pg.Builder.Update("businesses").
+		Set("checkin_count", squirrel.Expr("checkin_count + ?", response.CheckIns)).
+		Where("id = ?", targetID))
+	if err != nil {
+		return response, err
+	}
+
+	_, err = exec(r.pg.Builder.Update("businesses").
+		Set("merged_into", targetID).
+		Set("status", entity.ContentStatusHidden).
+		Set("checkin_count", 0).
+		Set("updated_at", time.Now().Format(time.RFC3339)).
+		Where("id = ?", sourceID))
+	if err != nil {
+		return response, err
+	}
+
+	return response, tx.Commit(ctx)
+}
@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"yalp_ulab/internal/entity"
+)
+
+func TestBusinessContent_WithoutAttachments(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments []string
+		want        int
+	}{
+		{"left out", nil, 0},
+		{"empty", []string{}, 0},
+		{"set", []string{"https://example.com/a.jpg"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := businessContent(entity.Business{Name: "Cafe", Attachments: tt.attachments})
+
+			attachments, ok := mp["attachments"].([]string)
+			if !ok || attachments == nil {
+				t.Fatalf("attachments = %#v, want a non-nil []string so pgx doesn't write NULL", mp["attachments"])
+			}
+			if len(attachments) != tt.want {
+				t.Fatalf("len(attachments) = %d, want %d", len(attachments), tt.want)
+			}
+		})
+	}
+}
+
+func TestBusinessContent_BuildsInsert(t *testing.T) {
+	mp := businessContent(entity.Business{Name: "Cafe"})
+	mp["id"] = "66666666-6666-6666-6666-666666666666"
+
+	_, args, err := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Insert("businesses").SetMap(mp).ToSql()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, arg := range args {
+		if s, ok := arg.([]string); ok && s == nil {
+			t.Fatal("insert has a nil []string argument")
+		}
+	}
+}
+
+func TestRollbackContent_RestoresOnlyContent(t *testing.T) {
+	snapshot := entity.BusinessSnapshot{
+		Name:              "Cafe",
+		Description:       "Plov and tea",
+		PriceLevel:        2,
+		Attributes:        map[string]interface{}{"wifi": true},
+		ClosedPermanently: true,
+	}
+
+	mp := rollbackContent(snapshot, "hash")
+
+	want := []string{"business_name", "location", "category_ids", "description", "contact_information", "attachments",
+		"price_level", "text_hash", "attributes", "opening_hours", "closed_permanently"}
+	if len(mp) != len(want) {
+		t.Fatalf("rollback sets %d columns, want %d: %v", len(mp), len(want), mp)
+	}
+	for _, column := range want {
+		if _, ok := mp[column]; !ok {
+			t.Fatalf("rollback doesn't restore %s", column)
+		}
+	}
+
+	for _, column := range []string{"id", "status", "created_by", "merged_into", "created_at"} {
+		if _, ok := mp[column]; ok {
+			t.Fatalf("rollback must not change %s", column)
+		}
+	}
+
+	if mp["business_name"] != "Cafe" || mp["text_hash"] != "hash" || mp["closed_permanently"] != true {
+		t.Fatalf("rollback doesn't restore the snapshot: %v", mp)
+	}
+	if attachments, ok := mp["attachments"].([]string); !ok || attachments == nil {
+		t.Fatalf("attachments = %#v, want a non-nil []string", mp["attachments"])
+	}
+}
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const categoryColumns = `id, COALESCE(parent_id::text, ''), slug, names, icon, position, created_at, updated_at`
+
+type CategoryRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewCategoryRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *CategoryRepo {
+	return &CategoryRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *CategoryRepo) Create(ctx context.Context, req entity.Category) (entity.Category, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("categories").
+		Columns(`id, parent_id, slug, names, icon, position`).
+		Values(req.ID, nullString(req.ParentID), req.Slug, req.Names, req.Icon, req.Position).
+		Suffix("RETURNING " + categoryColumns).ToSql()
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	return scanCategory(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *CategoryRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Category, error) {
+	query, args, err := r.pg.Builder.
+		Select(categoryColumns).
+		From("categories").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	return scanCategory(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *CategoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CategoryList, error) {
+	response := entity.CategoryList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(categoryColumns).
+		From("categories")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanCategory(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("categories").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *CategoryRepo) Update(ctx context.Context, req entity.Category) (entity.Category, error) {
+	mp := map[string]interface{}{
+		"parent_id":  nullString(req.ParentID),
+		"slug":       req.Slug,
+		"names":      req.Names,
+		"icon":       req.Icon,
+		"position":   req.Position,
+		"updated_at": time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("categories").SetMap(mp).Where("id = ?", req.ID).
+		Suffix("RETURNING " + categoryColumns).ToSql()
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	return scanCategory(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// Delete removes a category without children and takes it off every business.
+func (r *CategoryRepo) Delete(ctx context.Context, req entity.Id) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.pg.Builder.Update("businesses").
+		Set("category_ids", squirrel.Expr("array_remove(category_ids, ?::uuid)", req.ID)).
+		Where("? = ANY(category_ids)", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	query, args, err = r.pg.Builder.Delete("categories").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanCategory(row rowScanner) (entity.Category, error) {
+	var (
+		item                 entity.Category
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.ParentID, &item.Slug, &item.Names, &item.Icon, &item.Position, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
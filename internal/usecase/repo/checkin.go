@@ -0,0 +1,183 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+type CheckInRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewCheckInRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *CheckInRepo {
+	return &CheckInRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create records the check-in, entity.ErrCheckInCooldown if the user checked in at the business within
+// config.CheckInCooldown and entity.ErrCheckInDailyLimit if they already have dailyLimit check-ins in the last 24 hours.
+// A dailyLimit of 0 means no limit.
+func (r *CheckInRepo) Create(ctx context.Context, req entity.CheckIn, dailyLimit int) (entity.CheckIn, error) {
+	var createdAt time.Time
+
+	req.ID = uuid.NewString()
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.checkLimits(ctx, tx, req, dailyLimit)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	query, args, err := r.pg.Builder.Insert("checkins").
+		Columns(`id, user_id, business_id, distance_meters`).
+		Values(req.ID, req.UserID, req.BusinessID, req.DistanceMeters).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	err = tx.QueryRow(ctx, query, args...).Scan(&createdAt)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.CheckIn{}, err
+	}
+
+	req.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return req, nil
+}
+
+// checkLimits counts the recent check-ins of the user under a lock on the user, held until tx ends,
+// so that concurrent check-ins can't both pass the count before either is inserted.
+func (r *CheckInRepo) checkLimits(ctx context.Context, tx pgx.Tx, req entity.CheckIn, dailyLimit int) error {
+	query, args, err := r.pg.Builder.Select().
+		Column(squirrel.Expr("pg_advisory_xact_lock(hashtext(?))", req.UserID)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	count := func(where squirrel.Sqlizer) (int, error) {
+		var n int
+
+		query, args, err := r.pg.Builder.Select("COUNT(1)").From("checkins").Where(where).ToSql()
+		if err != nil {
+			return 0, err
+		}
+
+		err = tx.QueryRow(ctx, query, args...).Scan(&n)
+
+		return n, err
+	}
+
+	now := time.Now()
+
+	recent, err := count(squirrel.And{
+		squirrel.Eq{"user_id": req.UserID, "business_id": req.BusinessID},
+		squirrel.GtOrEq{"created_at": now.Add(-config.CheckInCooldown).Format(time.RFC3339)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if recent > 0 {
+		return entity.ErrCheckInCooldown
+	}
+
+	if dailyLimit <= 0 {
+		return nil
+	}
+
+	today, err := count(squirrel.And{
+		squirrel.Eq{"user_id": req.UserID},
+		squirrel.GtOrEq{"created_at": now.Add(-24 * time.Hour).Format(time.RFC3339)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if today >= dailyLimit {
+		return entity.ErrCheckInDailyLimit
+	}
+
+	return nil
+}
+
+// GetList returns check-ins with the name of the business, filtered and ordered on c (checkins).
+func (r *CheckInRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CheckInList, error) {
+	var (
+		response  = entity.CheckInList{}
+		createdAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`c.id, c.user_id, c.business_id, b.business_name, c.distance_meters, c.created_at`).
+		From("checkins c").
+		Join("businesses b ON b.id = c.business_id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.CheckIn
+		err = rows.Scan(&item.ID, &item.UserID, &item.BusinessID, &item.BusinessName, &item.DistanceMeters, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").
+		From("checkins c").
+		Join("businesses b ON b.id = c.business_id").
+		Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
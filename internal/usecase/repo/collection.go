@@ -0,0 +1,285 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const collectionColumns = `id, user_id, name, description, is_public, item_count, created_at, updated_at`
+
+type CollectionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewCollectionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *CollectionRepo {
+	return &CollectionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, req entity.Collection) (entity.Collection, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("collections").
+		Columns(`id, user_id, name, description, is_public`).
+		Values(req.ID, req.UserID, req.Name, req.Description, req.IsPublic).
+		Suffix("RETURNING " + collectionColumns).ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return scanCollection(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *CollectionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Collection, error) {
+	query, args, err := r.pg.Builder.
+		Select(collectionColumns).
+		From("collections").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return scanCollection(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *CollectionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CollectionList, error) {
+	response := entity.CollectionList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(collectionColumns).
+		From("collections")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanCollection(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("collections").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, req entity.Collection) (entity.Collection, error) {
+	mp := map[string]interface{}{
+		"name":        req.Name,
+		"description": req.Description,
+		"is_public":   req.IsPublic,
+		"updated_at":  time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("collections").SetMap(mp).Where("id = ?", req.ID).
+		Suffix("RETURNING " + collectionColumns).ToSql()
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	return scanCollection(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("collections").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanCollection(row rowScanner) (entity.Collection, error) {
+	var (
+		item                 entity.Collection
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.UserID, &item.Name, &item.Description, &item.IsPublic, &item.ItemCount, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Collection{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
+
+type CollectionItemRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewCollectionItemRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *CollectionItemRepo {
+	return &CollectionItemRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *CollectionItemRepo) Create(ctx context.Context, req entity.CollectionItem) (entity.CollectionItem, error) {
+	query, args, err := r.pg.Builder.Insert("collection_items").
+		Columns(`collection_id, business_id, user_id, note`).
+		Values(req.CollectionID, req.BusinessID, req.UserID, req.Note).ToSql()
+	if err != nil {
+		return entity.CollectionItem{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.CollectionItem{}, err
+	}
+
+	return req, nil
+}
+
+// GetList returns saved businesses, filtered and ordered on ci (collection_items) and b (businesses).
+func (r *CollectionItemRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.CollectionItemList, error) {
+	var (
+		response  = entity.CollectionItemList{}
+		createdAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`ci.collection_id, ci.business_id, ci.user_id, ci.note, ci.created_at,
+			b.business_name, b.location, ` + categorySlugs("b") + `, b.description, b.attachments, b.price_level, b.status, b.created_by`).
+		From("collection_items ci").
+		Join("businesses b ON b.id = ci.business_id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.CollectionItem
+		err = rows.Scan(&item.CollectionID, &item.BusinessID, &item.UserID, &item.Note, &createdAt,
+			&item.Business.Name, &item.Business.Location, &item.Business.Categories, &item.Business.Description,
+			&item.Business.Attachments, &item.Business.PriceLevel, &item.Business.Status, &item.Business.CreatedBy)
+		if err != nil {
+			return response, err
+		}
+
+		item.Business.ID = item.BusinessID
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").
+		From("collection_items ci").
+		Join("businesses b ON b.id = ci.business_id").
+		Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *CollectionItemRepo) Delete(ctx context.Context, req entity.CollectionItem) (entity.RowsEffected, error) {
+	response := entity.RowsEffected{}
+
+	query, args, err := r.pg.Builder.Delete("collection_items").
+		Where("collection_id = ? AND business_id = ?", req.CollectionID, req.BusinessID).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// GetBookmarked returns which of the given businesses the user saved in any of their collections.
+func (r *CollectionItemRepo) GetBookmarked(ctx context.Context, userID string, businessIDs []string) ([]string, error) {
+	var response []string
+
+	if userID == "" || len(businessIDs) == 0 {
+		return response, nil
+	}
+
+	query, args, err := r.pg.Builder.
+		Select(`DISTINCT business_id`).
+		From("collection_items").
+		Where("user_id = ? AND business_id = ANY(?)", userID, businessIDs).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var businessID string
+		err = rows.Scan(&businessID)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, businessID)
+	}
+
+	return response, rows.Err()
+}
@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+// feedSources is every kind of activity that shows up in the feed, with the same columns.
+// Check-ins of users who keep their visit history private are left out.
+const feedSources = `(
+	SELECT 'review' AS type, id, user_id, business_id, rating, text, '' AS url, created_at FROM reviews WHERE status = 'published'
+	UNION ALL
+	SELECT 'checkin', c.id, c.user_id, c.business_id, 0, '', '', c.created_at FROM checkins c
+		JOIN users u ON u.id = c.user_id WHERE u.privacy->>'checkins' <> 'private'
+	UNION ALL
+	SELECT 'photo', id, user_id, business_id, 0, caption, url, created_at FROM photos WHERE status = 'published'
+) f`
+
+type FollowRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewFollowRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *FollowRepo {
+	return &FollowRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create follows a user. Following someone twice is a no-op.
+func (r *FollowRepo) Create(ctx context.Context, req entity.Follow) error {
+	query, args, err := r.pg.Builder.Insert("follows").
+		Columns(`follower_id, followee_id`).
+		Values(req.FollowerID, req.FolloweeID).
+		Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
+
+func (r *FollowRepo) Delete(ctx context.Context, req entity.Follow) (entity.RowsEffected, error) {
+	response := entity.RowsEffected{}
+
+	query, args, err := r.pg.Builder.Delete("follows").
+		Where("follower_id = ? AND followee_id = ?", req.FollowerID, req.FolloweeID).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// Exists reports whether req.FollowerID follows req.FolloweeID.
+func (r *FollowRepo) Exists(ctx context.Context, req entity.Follow) (bool, error) {
+	var exists bool
+
+	query, args, err := r.pg.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("follows").
+		Where("follower_id = ? AND followee_id = ?", req.FollowerID, req.FolloweeID).
+		Suffix(")").ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&exists)
+
+	return exists, err
+}
+
+// GetFollowers lists who follows a user, filter on f.followee_id.
+func (r *FollowRepo) GetFollowers(ctx context.Context, req entity.GetListFilter) (entity.FollowUserList, error) {
+	return r.getList(ctx, req, "f.follower_id")
+}
+
+// GetFollowing lists who a user follows, filter on f.follower_id.
+func (r *FollowRepo) GetFollowing(ctx context.Context, req entity.GetListFilter) (entity.FollowUserList, error) {
+	return r.getList(ctx, req, "f.followee_id")
+}
+
+// getList lists the users on the userColumn side of the follows.
+func (r *FollowRepo) getList(ctx context.Context, req entity.GetListFilter, userColumn string) (entity.FollowUserList, error) {
+	var (
+		response  = entity.FollowUserList{}
+		createdAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`u.id, u.display_name, u.full_name, u.avatar_url, f.created_at`).
+		From("follows f").
+		Join("users u ON u.id = " + userColumn)
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item     entity.FollowUser
+			fullName string
+		)
+
+		err = rows.Scan(&item.UserID, &item.DisplayName, &fullName, &item.AvatarURL, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.DisplayName = entity.PublicName(item.DisplayName, fullName)
+		item.FollowedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("follows f").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// GetFeed reads the activity of the users req.UserID follows, newest first, one page at a time.
+// Pages are keyed on (created_at, id) at second precision, which is what the cursor carries.
+func (r *FollowRepo) GetFeed(ctx context.Context, req entity.FeedRequest) ([]entity.FeedItem, error) {
+	var response []entity.FeedItem
+
+	queryBuilder := r.pg.Builder.
+		Select(`f.type, f.id, f.user_id, f.business_id, b.business_name, f.rating, f.text, f.url, f.created_at`).
+		From(feedSources).
+		Join("businesses b ON b.id = f.business_id AND b.status = 'published'").
+		Where("f.user_id IN (SELECT followee_id FROM follows WHERE follower_id = ?)", req.UserID).
+		OrderBy("date_trunc('second', f.created_at) DESC", "f.id DESC").
+		Limit(uint64(req.Limit))
+
+	if req.CursorCreatedAt != "" {
+		queryBuilder = queryBuilder.Where("(date_trunc('second', f.created_at), f.id) < (?::timestamp, ?::uuid)",
+			req.CursorCreatedAt, req.CursorID)
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item      entity.FeedItem
+			createdAt time.Time
+		)
+
+		err = rows.Scan(&item.Type, &item.ID, &item.UserID, &item.BusinessID, &item.BusinessName,
+			&item.Rating, &item.Text, &item.URL, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
@@ -1,6 +1,9 @@
 package repo
 
 import (
+	"database/sql"
+	"strings"
+
 	"github.com/Masterminds/squirrel"
 	"yalp_ulab/internal/entity"
 )
@@ -23,6 +26,13 @@ func PrepareFilter(filters []entity.Filter) squirrel.And {
 			where = append(where, squirrel.Lt{e.Column: e.Value})
 		case "lte":
 			where = append(where, squirrel.LtOrEq{e.Column: e.Value})
+		case "in":
+			where = append(where, squirrel.Eq{e.Column: strings.Split(e.Value, ",")})
+		case "overlap":
+			where = append(where, squirrel.Expr(e.Column+" && ?", strings.Split(e.Value, ",")))
+		case "jsonpath":
+			// @? is escaped, squirrel would take its question mark for a placeholder
+			where = append(where, squirrel.Expr(e.Column+" @?? ?::jsonpath", e.Value))
 		case "search":
 			or = append(or, squirrel.ILike{e.Column: "%" + e.Value + "%"})
 		}
@@ -56,3 +66,8 @@ func PrepareGetListQuery(selectQuery squirrel.SelectBuilder, filterRequest entit
 
 	return selectQuery, where
 }
+
+// nullString maps an empty string to SQL NULL, e.g. for optional uuid columns.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,149 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const menuColumns = `id, business_id, kind, name, description, currency, position, sections, created_at, updated_at`
+
+type MenuRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewMenuRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *MenuRepo {
+	return &MenuRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *MenuRepo) Create(ctx context.Context, req entity.Menu) (entity.Menu, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("menus").
+		Columns(`id, business_id, kind, name, description, currency, position, sections`).
+		Values(req.ID, req.BusinessID, req.Kind, req.Name, req.Description, req.Currency, req.Position, req.Sections).
+		Suffix("RETURNING " + menuColumns).ToSql()
+	if err != nil {
+		return entity.Menu{}, err
+	}
+
+	return scanMenu(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *MenuRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Menu, error) {
+	query, args, err := r.pg.Builder.
+		Select(menuColumns).
+		From("menus").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Menu{}, err
+	}
+
+	return scanMenu(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *MenuRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.MenuList, error) {
+	response := entity.MenuList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(menuColumns).
+		From("menus")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanMenu(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("menus").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// Update replaces the menu with all its sections and items.
+func (r *MenuRepo) Update(ctx context.Context, req entity.Menu) (entity.Menu, error) {
+	mp := map[string]interface{}{
+		"kind":        req.Kind,
+		"name":        req.Name,
+		"description": req.Description,
+		"currency":    req.Currency,
+		"position":    req.Position,
+		"sections":    req.Sections,
+		"updated_at":  time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("menus").SetMap(mp).Where("id = ?", req.ID).
+		Suffix("RETURNING " + menuColumns).ToSql()
+	if err != nil {
+		return entity.Menu{}, err
+	}
+
+	return scanMenu(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *MenuRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("menus").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func scanMenu(row rowScanner) (entity.Menu, error) {
+	var (
+		item                 entity.Menu
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.Kind, &item.Name, &item.Description, &item.Currency, &item.Position,
+		&item.Sections, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Menu{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -0,0 +1,326 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const moderationCaseColumns = `id, target_type, target_id, COALESCE(target_owner_id::text, ''), status, report_count,
+	COALESCE(assignee_id::text, ''), reason_code, action, note, created_at, updated_at, resolved_at`
+
+type ModerationCaseRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewModerationCaseRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ModerationCaseRepo {
+	return &ModerationCaseRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Open adds req.ReportCount reports to the unresolved case of the target, creating the case when there is none.
+// The content filter opens cases with no reports.
+func (r *ModerationCaseRepo) Open(ctx context.Context, req entity.ModerationCase) (entity.ModerationCase, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("moderation_cases").
+		Columns(`id, target_type, target_id, target_owner_id, status, report_count, reason_code, note`).
+		Values(req.ID, req.TargetType, req.TargetID, nullString(req.TargetOwnerID), entity.ModerationStatusOpen, req.ReportCount, req.ReasonCode, req.Note).
+		Suffix(`ON CONFLICT (target_type, target_id) WHERE status IN ('open', 'in_review')
+			DO UPDATE SET report_count = moderation_cases.report_count + EXCLUDED.report_count, updated_at = now()
+			RETURNING ` + moderationCaseColumns).ToSql()
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+
+	return scanModerationCase(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *ModerationCaseRepo) GetSingle(ctx context.Context, req entity.Id) (entity.ModerationCase, error) {
+	query, args, err := r.pg.Builder.
+		Select(moderationCaseColumns).
+		From("moderation_cases").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+
+	return scanModerationCase(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *ModerationCaseRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ModerationCaseList, error) {
+	response := entity.ModerationCaseList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(moderationCaseColumns).
+		From("moderation_cases")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanModerationCase(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("moderation_cases").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *ModerationCaseRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	query, args, err := r.pg.Builder.Update("moderation_cases").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// moderationTables are the table of each reportable target type
+var moderationTables = map[string]string{
+	entity.ReportTargetBusiness:    "businesses",
+	entity.ReportTargetReview:      "reviews",
+	entity.ReportTargetReviewReply: "review_replies",
+	entity.ReportTargetPhoto:       "photos",
+}
+
+// Apply moves an unresolved case, its reports and its content and appends the history in one transaction,
+// entity.ErrModerationCaseResolved if the case was resolved in the meantime.
+func (r *ModerationCaseRepo) Apply(ctx context.Context, req entity.ModerationStep) error {
+	table, ok := moderationTables[req.Case.TargetType]
+	if !ok {
+		return fmt.Errorf("ModerationCaseRepo - Apply - unknown target type %q", req.Case.TargetType)
+	}
+
+	if req.ContentStatus != "" && req.Case.TargetType == entity.ReportTargetReviewReply {
+		return fmt.Errorf("ModerationCaseRepo - Apply - %q has no status", req.Case.TargetType)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+
+	mp := map[string]interface{}{
+		"status":      req.Status,
+		"assignee_id": nullString(req.History.ActorID),
+		"updated_at":  now,
+	}
+	if req.Status == entity.ModerationStatusActioned || req.Status == entity.ModerationStatusDismissed {
+		mp["action"] = req.History.Action
+		mp["reason_code"] = req.History.ReasonCode
+		mp["note"] = req.History.Note
+		mp["resolved_at"] = now
+	}
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	exec := func(b squirrel.Sqlizer) (int, error) {
+		query, args, err := b.ToSql()
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := tx.Exec(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return int(n.RowsAffected()), nil
+	}
+
+	n, err := exec(r.pg.Builder.Update("moderation_cases").SetMap(mp).
+		Where("id = ? AND status IN (?, ?)", req.Case.ID, entity.ModerationStatusOpen, entity.ModerationStatusInReview))
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return entity.ErrModerationCaseResolved
+	}
+
+	_, err = exec(r.pg.Builder.Update("reports").
+		Set("status", req.Status).
+		Set("updated_at", now).
+		Where("case_id = ?", req.Case.ID))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case req.DeleteContent:
+		_, err = exec(r.pg.Builder.Delete(table).Where("id = ?", req.Case.TargetID))
+	case req.ContentStatus != "":
+		content := r.pg.Builder.Update(table).Set("status", req.ContentStatus).Where("id = ?", req.Case.TargetID)
+		if req.ContentFrom != "" {
+			content = content.Where("status = ?", req.ContentFrom)
+		}
+		_, err = exec(content)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = exec(r.pg.Builder.Insert("moderation_history").
+		Columns(`id, case_id, actor_id, action, reason_code, note`).
+		Values(uuid.NewString(), req.Case.ID, nullString(req.History.ActorID), req.History.Action, req.History.ReasonCode, req.History.Note))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanModerationCase(row rowScanner) (entity.ModerationCase, error) {
+	var (
+		item                 entity.ModerationCase
+		createdAt, updatedAt time.Time
+		resolvedAt           sql.NullTime
+	)
+
+	err := row.Scan(&item.ID, &item.TargetType, &item.TargetID, &item.TargetOwnerID, &item.Status, &item.ReportCount,
+		&item.AssigneeID, &item.ReasonCode, &item.Action, &item.Note, &createdAt, &updatedAt, &resolvedAt)
+	if err != nil {
+		return entity.ModerationCase{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+	if resolvedAt.Valid {
+		item.ResolvedAt = resolvedAt.Time.Format(time.RFC3339)
+	}
+
+	return item, nil
+}
+
+// ModerationHistoryRepo stores the append-only log of moderation steps
+type ModerationHistoryRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewModerationHistoryRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ModerationHistoryRepo {
+	return &ModerationHistoryRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ModerationHistoryRepo) Create(ctx context.Context, req entity.ModerationHistory) (entity.ModerationHistory, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("moderation_history").
+		Columns(`id, case_id, actor_id, action, reason_code, note`).
+		Values(req.ID, req.CaseID, nullString(req.ActorID), req.Action, req.ReasonCode, req.Note).ToSql()
+	if err != nil {
+		return entity.ModerationHistory{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.ModerationHistory{}, err
+	}
+
+	return req, nil
+}
+
+func (r *ModerationHistoryRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ModerationHistoryList, error) {
+	var (
+		response  = entity.ModerationHistoryList{}
+		createdAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, case_id, COALESCE(actor_id::text, ''), action, reason_code, note, created_at`).
+		From("moderation_history")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.ModerationHistory
+		err = rows.Scan(&item.ID, &item.CaseID, &item.ActorID, &item.Action, &item.ReasonCode, &item.Note, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("moderation_history").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+type PhotoRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewPhotoRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PhotoRepo {
+	return &PhotoRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *PhotoRepo) Create(ctx context.Context, req entity.Photo) (entity.Photo, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("photos").
+		Columns(`id, business_id, user_id, url, caption, status`).
+		Values(req.ID, req.BusinessID, req.UserID, req.URL, req.Caption, req.Status).ToSql()
+	if err != nil {
+		return entity.Photo{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Photo{}, err
+	}
+
+	return req, nil
+}
+
+func (r *PhotoRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Photo, error) {
+	var (
+		response  entity.Photo
+		createdAt time.Time
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`id, business_id, user_id, url, caption, status, created_at`).
+		From("photos").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Photo{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.ID, &response.BusinessID, &response.UserID, &response.URL, &response.Caption, &response.Status, &createdAt)
+	if err != nil {
+		return entity.Photo{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *PhotoRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.PhotoList, error) {
+	var (
+		response  = entity.PhotoList{}
+		createdAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, business_id, user_id, url, caption, status, created_at`).
+		From("photos")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.Photo
+		err = rows.Scan(&item.ID, &item.BusinessID, &item.UserID, &item.URL, &item.Caption, &item.Status, &createdAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("photos").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *PhotoRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("photos").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PhotoRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	query, args, err := r.pg.Builder.Update("photos").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
@@ -0,0 +1,207 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/casbin/casbin/model"
+	"github.com/casbin/casbin/persist"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+// policyColumns are the value columns of casbin_rule, in order.
+var policyColumns = []string{"v0", "v1", "v2", "v3", "v4", "v5"}
+
+// PolicyRepo stores Casbin rules in Postgres. It implements persist.Adapter.
+type PolicyRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+var _ persist.Adapter = (*PolicyRepo)(nil)
+
+func NewPolicyRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PolicyRepo {
+	return &PolicyRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *PolicyRepo) Create(ctx context.Context, req entity.PolicyRule) error {
+	if len(req.Rule) == 0 || len(req.Rule) > len(policyColumns) {
+		return errors.New("PolicyRepo - Create - invalid rule")
+	}
+
+	query, args, err := r.pg.Builder.Insert("casbin_rule").
+		Columns(append([]string{"ptype"}, policyColumns[:len(req.Rule)]...)...).
+		Values(append([]interface{}{req.PType}, toInterfaces(req.Rule)...)...).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PolicyRepo) GetList(ctx context.Context) ([]entity.PolicyRule, error) {
+	var response []entity.PolicyRule
+
+	query, args, err := r.pg.Builder.
+		Select(append([]string{"ptype"}, policyColumns...)...).
+		From("casbin_rule").
+		OrderBy("id").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item   entity.PolicyRule
+			values = make([]string, len(policyColumns))
+			dest   = []interface{}{&item.PType}
+		)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+
+		err = rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+
+		// trailing empty columns are not part of the rule
+		for len(values) > 0 && values[len(values)-1] == "" {
+			values = values[:len(values)-1]
+		}
+		item.Rule = values
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
+
+// Delete removes rules of the given type whose leading values equal req.Rule. Empty values match anything.
+func (r *PolicyRepo) Delete(ctx context.Context, req entity.PolicyRule) (entity.RowsEffected, error) {
+	response := entity.RowsEffected{}
+
+	where := squirrel.And{squirrel.Eq{"ptype": req.PType}}
+	for i, value := range req.Rule {
+		if i >= len(policyColumns) {
+			break
+		}
+		if value != "" {
+			where = append(where, squirrel.Eq{policyColumns[i]: value})
+		}
+	}
+
+	query, args, err := r.pg.Builder.Delete("casbin_rule").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// LoadPolicy loads all policy rules from the storage.
+func (r *PolicyRepo) LoadPolicy(model model.Model) error {
+	rules, err := r.GetList(context.Background())
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		persist.LoadPolicyLine(rule.PType+", "+strings.Join(rule.Rule, ", "), model)
+	}
+
+	return nil
+}
+
+// SavePolicy replaces every stored rule with the rules of the model.
+func (r *PolicyRepo) SavePolicy(model model.Model) error {
+	ctx := context.Background()
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, "DELETE FROM casbin_rule")
+	if err != nil {
+		return err
+	}
+
+	for _, sec := range []string{entity.PolicyTypePolicy, entity.PolicyTypeRole} {
+		for ptype, assertion := range model[sec] {
+			for _, rule := range assertion.Policy {
+				query, args, err := r.pg.Builder.Insert("casbin_rule").
+					Columns(append([]string{"ptype"}, policyColumns[:len(rule)]...)...).
+					Values(append([]interface{}{ptype}, toInterfaces(rule)...)...).ToSql()
+				if err != nil {
+					return err
+				}
+
+				_, err = tx.Exec(ctx, query, args...)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AddPolicy adds a policy rule to the storage.
+func (r *PolicyRepo) AddPolicy(sec string, ptype string, rule []string) error {
+	return r.Create(context.Background(), entity.PolicyRule{PType: ptype, Rule: rule})
+}
+
+// RemovePolicy removes a policy rule from the storage.
+func (r *PolicyRepo) RemovePolicy(sec string, ptype string, rule []string) error {
+	_, err := r.Delete(context.Background(), entity.PolicyRule{PType: ptype, Rule: rule})
+	return err
+}
+
+// RemoveFilteredPolicy removes policy rules that match the filter from the storage.
+func (r *PolicyRepo) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	rule := make([]string, fieldIndex, fieldIndex+len(fieldValues))
+	rule = append(rule, fieldValues...)
+
+	_, err := r.Delete(context.Background(), entity.PolicyRule{PType: ptype, Rule: rule})
+	return err
+}
+
+func toInterfaces(values []string) []interface{} {
+	response := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		response = append(response, value)
+	}
+
+	return response
+}
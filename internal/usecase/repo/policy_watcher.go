@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/persist"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const (
+	policyChannel        = "casbin_policy"
+	policyListenInterval = time.Second
+)
+
+// PolicyWatcher keeps the Casbin policy of every instance in sync through Postgres LISTEN/NOTIFY.
+// It implements persist.Watcher.
+type PolicyWatcher struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+
+	mu       sync.RWMutex
+	callback func(string)
+	cancel   context.CancelFunc
+}
+
+var _ persist.Watcher = (*PolicyWatcher)(nil)
+
+// NewPolicyWatcher starts listening on a dedicated connection, so it never holds a pool connection.
+func NewPolicyWatcher(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PolicyWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &PolicyWatcher{
+		pg:     pg,
+		config: config,
+		logger: logger,
+		cancel: cancel,
+	}
+
+	go w.listen(ctx)
+
+	return w
+}
+
+// SetUpdateCallback sets the function called when the policy was changed by any instance.
+func (w *PolicyWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.callback = callback
+
+	return nil
+}
+
+// Update notifies every instance, this one included, that the policy has changed.
+func (w *PolicyWatcher) Update() error {
+	_, err := w.pg.Pool.Exec(context.Background(), "SELECT pg_notify($1, $2)", policyChannel, time.Now().Format(time.RFC3339Nano))
+	return err
+}
+
+// Close stops listening for notifications.
+func (w *PolicyWatcher) Close() {
+	w.cancel()
+}
+
+func (w *PolicyWatcher) listen(ctx context.Context) {
+	for {
+		err := w.waitForNotifications(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.logger.Error(fmt.Errorf("PolicyWatcher - listen: %w", err))
+		time.Sleep(policyListenInterval)
+	}
+}
+
+func (w *PolicyWatcher) waitForNotifications(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, w.pg.Pool.Config().ConnConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "LISTEN "+policyChannel)
+	if err != nil {
+		return err
+	}
+
+	// Changes made while we were not listening would otherwise be missed.
+	w.notify("listen")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		w.notify(notification.Payload)
+	}
+}
+
+func (w *PolicyWatcher) notify(payload string) {
+	w.mu.RLock()
+	callback := w.callback
+	w.mu.RUnlock()
+
+	if callback != nil {
+		callback(payload)
+	}
+}
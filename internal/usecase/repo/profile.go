@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+// ProfileRepo reads and writes the profile columns of users, with stats aggregated from their content.
+type ProfileRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewProfileRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ProfileRepo {
+	return &ProfileRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ProfileRepo) GetSingle(ctx context.Context, req entity.Id) (entity.ProfileRecord, error) {
+	var (
+		response  entity.ProfileRecord
+		createdAt time.Time
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`u.id, u.full_name, u.display_name, u.avatar_url, u.bio, u.city, u.status, u.privacy, u.created_at,
+			(SELECT COUNT(1) FROM reviews WHERE user_id = u.id AND status = 'published'),
+			(SELECT COALESCE(AVG(rating), 0) FROM reviews WHERE user_id = u.id AND status = 'published'),
+			(SELECT COUNT(1) FROM photos WHERE user_id = u.id AND status = 'published'),
+			(SELECT COUNT(1) FROM checkins WHERE user_id = u.id),
+			(SELECT COUNT(1) FROM follows WHERE followee_id = u.id),
+			(SELECT COUNT(1) FROM follows WHERE follower_id = u.id)`).
+		From("users u").
+		Where("u.id = ?", req.ID).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.ID, &response.FullName, &response.DisplayName, &response.AvatarURL, &response.Bio, &response.City,
+			&response.Status, &response.Privacy, &createdAt,
+			&response.Stats.ReviewCount, &response.Stats.AverageRatingGiven, &response.Stats.PhotoCount,
+			&response.Stats.CheckInCount, &response.Stats.FollowerCount, &response.Stats.FollowingCount)
+	if err != nil {
+		return entity.ProfileRecord{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *ProfileRepo) Update(ctx context.Context, req entity.ProfileRecord) error {
+	mp := map[string]interface{}{
+		"display_name": req.DisplayName,
+		"avatar_url":   req.AvatarURL,
+		"bio":          req.Bio,
+		"city":         req.City,
+		"privacy":      req.Privacy,
+		"updated_at":   time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("users").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
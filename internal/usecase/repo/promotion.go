@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const promotionColumns = `p.id, p.business_id, b.business_name, p.kind, p.title, p.description, p.weekdays, p.starts_at, p.ends_at,
+	p.created_at, p.updated_at`
+
+type PromotionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewPromotionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *PromotionRepo {
+	return &PromotionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *PromotionRepo) Create(ctx context.Context, req entity.Promotion) (entity.Promotion, error) {
+	var createdAt time.Time
+
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("promotions").
+		Columns(`id, business_id, kind, title, description, weekdays, starts_at, ends_at`).
+		Values(req.ID, req.BusinessID, req.Kind, req.Title, req.Description, req.Weekdays, req.StartsAt, req.EndsAt).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&createdAt)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	req.CreatedAt = createdAt.Format(time.RFC3339)
+	req.UpdatedAt = req.CreatedAt
+
+	return req, nil
+}
+
+func (r *PromotionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Promotion, error) {
+	query, args, err := r.pg.Builder.
+		Select(promotionColumns).
+		From("promotions p").
+		Join("businesses b ON b.id = p.business_id").
+		Where("p.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	return scanPromotion(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetList lists promotions, filter on p. (promotions) and b. (businesses) columns.
+func (r *PromotionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.PromotionList, error) {
+	response := entity.PromotionList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(promotionColumns).
+		From("promotions p").
+		Join("businesses b ON b.id = p.business_id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanPromotion(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").
+		From("promotions p").
+		Join("businesses b ON b.id = p.business_id").
+		Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *PromotionRepo) Update(ctx context.Context, req entity.Promotion) (entity.Promotion, error) {
+	var updatedAt time.Time
+
+	mp := map[string]interface{}{
+		"kind":        req.Kind,
+		"title":       req.Title,
+		"description": req.Description,
+		"weekdays":    req.Weekdays,
+		"starts_at":   req.StartsAt,
+		"ends_at":     req.EndsAt,
+		"updated_at":  time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("promotions").SetMap(mp).Where("id = ?", req.ID).
+		Suffix("RETURNING updated_at").ToSql()
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).Scan(&updatedAt)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	req.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return req, nil
+}
+
+func (r *PromotionRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("promotions").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
+
+func scanPromotion(row rowScanner) (entity.Promotion, error) {
+	var (
+		item                                   entity.Promotion
+		startsAt, endsAt, createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.BusinessName, &item.Kind, &item.Title, &item.Description, &item.Weekdays,
+		&startsAt, &endsAt, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Promotion{}, err
+	}
+
+	item.StartsAt = startsAt.Format(time.RFC3339)
+	item.EndsAt = endsAt.Format(time.RFC3339)
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -0,0 +1,382 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const questionColumns = `id, business_id, user_id, text, answer_count, upvote_count, COALESCE(accepted_answer_id::text, ''),
+	created_at, updated_at`
+
+// QuestionRepo stores questions about businesses. answer_count and upvote_count are kept in sync by triggers.
+type QuestionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewQuestionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *QuestionRepo {
+	return &QuestionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *QuestionRepo) Create(ctx context.Context, req entity.Question) (entity.Question, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("questions").
+		Columns(`id, business_id, user_id, text`).
+		Values(req.ID, req.BusinessID, req.UserID, req.Text).
+		Suffix("RETURNING " + questionColumns).ToSql()
+	if err != nil {
+		return entity.Question{}, err
+	}
+
+	return scanQuestion(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *QuestionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Question, error) {
+	query, args, err := r.pg.Builder.
+		Select(questionColumns).
+		From("questions").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Question{}, err
+	}
+
+	return scanQuestion(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *QuestionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.QuestionList, error) {
+	response := entity.QuestionList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(questionColumns).
+		From("questions")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanQuestion(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("questions").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// SetAcceptedAnswer marks an answer of the question as the accepted one, replacing the previous one.
+func (r *QuestionRepo) SetAcceptedAnswer(ctx context.Context, questionID, answerID string) error {
+	query, args, err := r.pg.Builder.Update("questions").
+		Set("accepted_answer_id", answerID).
+		Set("updated_at", time.Now().Format(time.RFC3339)).
+		Where("id = ?", questionID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
+
+func (r *QuestionRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("questions").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
+
+func scanQuestion(row rowScanner) (entity.Question, error) {
+	var (
+		item                 entity.Question
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.UserID, &item.Text, &item.AnswerCount, &item.UpvoteCount,
+		&item.AcceptedAnswerID, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Question{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
+
+const answerColumns = `a.id, a.question_id, a.user_id, a.text, a.upvote_count, a.user_id = b.created_by,
+	COALESCE(a.id = q.accepted_answer_id, false), a.created_at, a.updated_at`
+
+// AnswerRepo stores answers to questions. upvote_count is kept in sync by a trigger.
+type AnswerRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewAnswerRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *AnswerRepo {
+	return &AnswerRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *AnswerRepo) Create(ctx context.Context, req entity.Answer) (entity.Answer, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("answers").
+		Columns(`id, question_id, user_id, text`).
+		Values(req.ID, req.QuestionID, req.UserID, req.Text).ToSql()
+	if err != nil {
+		return entity.Answer{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Answer{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *AnswerRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Answer, error) {
+	query, args, err := r.pg.Builder.
+		Select(answerColumns).
+		From("answers a").
+		Join("questions q ON q.id = a.question_id").
+		Join("businesses b ON b.id = q.business_id").
+		Where("a.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Answer{}, err
+	}
+
+	return scanAnswer(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetList lists answers, filter on a. (answers) and q. (questions) columns.
+func (r *AnswerRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.AnswerList, error) {
+	response := entity.AnswerList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(answerColumns).
+		From("answers a").
+		Join("questions q ON q.id = a.question_id").
+		Join("businesses b ON b.id = q.business_id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanAnswer(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").
+		From("answers a").
+		Join("questions q ON q.id = a.question_id").
+		Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *AnswerRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("answers").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
+
+func scanAnswer(row rowScanner) (entity.Answer, error) {
+	var (
+		item                 entity.Answer
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.QuestionID, &item.UserID, &item.Text, &item.UpvoteCount, &item.ByOwner, &item.Accepted,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return entity.Answer{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
+
+// upvoteTables are the vote table and its target column of each upvote target
+var upvoteTables = map[string][2]string{
+	entity.UpvoteTargetQuestion: {"question_votes", "question_id"},
+	entity.UpvoteTargetAnswer:   {"answer_votes", "answer_id"},
+}
+
+// UpvoteRepo stores upvotes on questions and answers.
+type UpvoteRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewUpvoteRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *UpvoteRepo {
+	return &UpvoteRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func upvoteTable(target string) (table, column string, err error) {
+	t, ok := upvoteTables[target]
+	if !ok {
+		return "", "", fmt.Errorf("upvoteTable - unknown target %q", target)
+	}
+
+	return t[0], t[1], nil
+}
+
+func (r *UpvoteRepo) Create(ctx context.Context, req entity.Upvote) error {
+	table, column, err := upvoteTable(req.Target)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := r.pg.Builder.Insert(table).
+		Columns(column+", user_id").
+		Values(req.TargetID, req.UserID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+
+	return err
+}
+
+func (r *UpvoteRepo) Delete(ctx context.Context, req entity.Upvote) (entity.RowsEffected, error) {
+	response := entity.RowsEffected{}
+
+	table, column, err := upvoteTable(req.Target)
+	if err != nil {
+		return response, err
+	}
+
+	query, args, err := r.pg.Builder.Delete(table).
+		Where(column+" = ? AND user_id = ?", req.TargetID, req.UserID).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// GetList returns the upvotes a user cast on the given questions or answers.
+func (r *UpvoteRepo) GetList(ctx context.Context, userID, target string, targetIDs []string) ([]entity.Upvote, error) {
+	var response []entity.Upvote
+
+	if userID == "" || len(targetIDs) == 0 {
+		return response, nil
+	}
+
+	table, column, err := upvoteTable(target)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := r.pg.Builder.
+		Select(column+", user_id").
+		From(table).
+		Where("user_id = ? AND "+column+" = ANY(?)", userID, targetIDs).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := entity.Upvote{Target: target}
+		err = rows.Scan(&item.TargetID, &item.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
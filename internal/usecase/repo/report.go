@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+type ReportRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewReportRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReportRepo {
+	return &ReportRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReportRepo) Create(ctx context.Context, req entity.Report) (entity.Report, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("reports").
+		Columns(`id, case_id, target_type, target_id, reporter_id, reason_code, details, status`).
+		Values(req.ID, nullString(req.CaseID), req.TargetType, req.TargetID, req.ReporterID, req.ReasonCode, req.Details, req.Status).ToSql()
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	return req, nil
+}
+
+func (r *ReportRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Report, error) {
+	var (
+		response             entity.Report
+		createdAt, updatedAt time.Time
+	)
+
+	query, args, err := r.pg.Builder.
+		Select(`id, COALESCE(case_id::text, ''), target_type, target_id, reporter_id, reason_code, details, status, created_at, updated_at`).
+		From("reports").
+		Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.ID, &response.CaseID, &response.TargetType, &response.TargetID, &response.ReporterID, &response.ReasonCode,
+			&response.Details, &response.Status, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Report{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *ReportRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ReportList, error) {
+	var (
+		response             = entity.ReportList{}
+		createdAt, updatedAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, COALESCE(case_id::text, ''), target_type, target_id, reporter_id, reason_code, details, status, created_at, updated_at`).
+		From("reports")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.Report
+		err = rows.Scan(&item.ID, &item.CaseID, &item.TargetType, &item.TargetID, &item.ReporterID, &item.ReasonCode,
+			&item.Details, &item.Status, &createdAt, &updatedAt)
+		if err != nil {
+			return response, err
+		}
+
+		item.CreatedAt = createdAt.Format(time.RFC3339)
+		item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("reports").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *ReportRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	query, args, err := r.pg.Builder.Update("reports").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
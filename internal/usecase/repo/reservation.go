@@ -0,0 +1,339 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const reservationSettingsColumns = `business_id, enabled, slot_minutes, capacity, max_party_size, min_notice_minutes, max_days_ahead, updated_at`
+
+type ReservationSettingsRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewReservationSettingsRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReservationSettingsRepo {
+	return &ReservationSettingsRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// GetSingle reads the settings of a business, pgx.ErrNoRows if it never set them up.
+func (r *ReservationSettingsRepo) GetSingle(ctx context.Context, req entity.Id) (entity.ReservationSettings, error) {
+	query, args, err := r.pg.Builder.
+		Select(reservationSettingsColumns).
+		From("reservation_settings").
+		Where("business_id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.ReservationSettings{}, err
+	}
+
+	return scanReservationSettings(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *ReservationSettingsRepo) Upsert(ctx context.Context, req entity.ReservationSettings) (entity.ReservationSettings, error) {
+	query, args, err := r.pg.Builder.Insert("reservation_settings").
+		Columns(`business_id, enabled, slot_minutes, capacity, max_party_size, min_notice_minutes, max_days_ahead`).
+		Values(req.BusinessID, req.Enabled, req.SlotMinutes, req.Capacity, req.MaxPartySize, req.MinNoticeMinutes, req.MaxDaysAhead).
+		Suffix(`ON CONFLICT (business_id) DO UPDATE SET enabled = EXCLUDED.enabled, slot_minutes = EXCLUDED.slot_minutes,
+			capacity = EXCLUDED.capacity, max_party_size = EXCLUDED.max_party_size, min_notice_minutes = EXCLUDED.min_notice_minutes,
+			max_days_ahead = EXCLUDED.max_days_ahead, updated_at = now()
+			RETURNING ` + reservationSettingsColumns).ToSql()
+	if err != nil {
+		return entity.ReservationSettings{}, err
+	}
+
+	return scanReservationSettings(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func scanReservationSettings(row rowScanner) (entity.ReservationSettings, error) {
+	var (
+		item      entity.ReservationSettings
+		updatedAt time.Time
+	)
+
+	err := row.Scan(&item.BusinessID, &item.Enabled, &item.SlotMinutes, &item.Capacity, &item.MaxPartySize,
+		&item.MinNoticeMinutes, &item.MaxDaysAhead, &updatedAt)
+	if err != nil {
+		return entity.ReservationSettings{}, err
+	}
+
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
+
+type ReservationRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewReservationRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReservationRepo {
+	return &ReservationRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+// reserve locks the reservations of the business for the rest of the transaction and checks that
+// req.PartySize more guests fit in the slot, not counting req itself when it is being rescheduled.
+func (r *ReservationRepo) reserve(ctx context.Context, tx pgx.Tx, req entity.Reservation, capacity int) error {
+	query, args, err := r.pg.Builder.Select().
+		Column(squirrel.Expr("pg_advisory_xact_lock(hashtext(?))", req.BusinessID)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	var booked int
+
+	query, args, err = r.pg.Builder.
+		Select("COALESCE(SUM(party_size), 0)").
+		From("reservations").
+		Where("business_id = ? AND starts_at = ? AND status = ?", req.BusinessID, req.StartsAt, entity.ReservationStatusConfirmed).
+		Where(squirrel.NotEq{"id": req.ID}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, query, args...).Scan(&booked)
+	if err != nil {
+		return err
+	}
+
+	if booked+req.PartySize > capacity {
+		return entity.ErrSlotFull
+	}
+
+	return nil
+}
+
+// Create books the reservation, entity.ErrSlotFull if the slot has no room for it.
+func (r *ReservationRepo) Create(ctx context.Context, req entity.Reservation, capacity int) (entity.Reservation, error) {
+	var createdAt time.Time
+
+	req.ID = uuid.NewString()
+	req.Status = entity.ReservationStatusConfirmed
+
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.reserve(ctx, tx, req, capacity)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	query, args, err := r.pg.Builder.Insert("reservations").
+		Columns(`id, business_id, user_id, party_size, starts_at, status, note`).
+		Values(req.ID, req.BusinessID, req.UserID, req.PartySize, req.StartsAt, req.Status, req.Note).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	err = tx.QueryRow(ctx, query, args...).Scan(&createdAt)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	req.CreatedAt = createdAt.Format(time.RFC3339)
+	req.UpdatedAt = req.CreatedAt
+
+	return req, tx.Commit(ctx)
+}
+
+// Reschedule moves the reservation to req.StartsAt for req.PartySize guests, entity.ErrSlotFull if the slot has no room for it
+// and entity.ErrReservationCancelled if the reservation is no longer confirmed.
+func (r *ReservationRepo) Reschedule(ctx context.Context, req entity.Reservation, capacity int) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.reserve(ctx, tx, req, capacity)
+	if err != nil {
+		return err
+	}
+
+	mp := map[string]interface{}{
+		"starts_at":  req.StartsAt,
+		"party_size": req.PartySize,
+		"updated_at": time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("reservations").SetMap(mp).
+		Where("id = ? AND status = ?", req.ID, entity.ReservationStatusConfirmed).ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrReservationCancelled
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ReservationRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Reservation, error) {
+	query, args, err := r.pg.Builder.
+		Select(`r.id, r.business_id, b.business_name, r.user_id, r.party_size, r.starts_at, r.status, r.note, r.created_at, r.updated_at`).
+		From("reservations r").
+		Join("businesses b ON b.id = r.business_id").
+		Where("r.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	return scanReservation(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetList lists reservations, filter on r. (reservations) and b. (businesses) columns.
+func (r *ReservationRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ReservationList, error) {
+	response := entity.ReservationList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(`r.id, r.business_id, b.business_name, r.user_id, r.party_size, r.starts_at, r.status, r.note, r.created_at, r.updated_at`).
+		From("reservations r").
+		Join("businesses b ON b.id = r.business_id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanReservation(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").
+		From("reservations r").
+		Join("businesses b ON b.id = r.business_id").
+		Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// CountBooked sums the guests of the confirmed reservations of a business by slot, from inclusive and to exclusive.
+func (r *ReservationRepo) CountBooked(ctx context.Context, businessID, from, to string) (map[string]int, error) {
+	query, args, err := r.pg.Builder.
+		Select("starts_at, SUM(party_size)").
+		From("reservations").
+		Where("business_id = ? AND status = ?", businessID, entity.ReservationStatusConfirmed).
+		Where("starts_at >= ? AND starts_at < ?", from, to).
+		GroupBy("starts_at").ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	booked := map[string]int{}
+	for rows.Next() {
+		var (
+			startsAt time.Time
+			guests   int
+		)
+
+		err = rows.Scan(&startsAt, &guests)
+		if err != nil {
+			return nil, err
+		}
+
+		booked[startsAt.Format(time.RFC3339)] = guests
+	}
+
+	return booked, rows.Err()
+}
+
+func (r *ReservationRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	query, args, err := r.pg.Builder.Update("reservations").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+func scanReservation(row rowScanner) (entity.Reservation, error) {
+	var (
+		item                           entity.Reservation
+		startsAt, createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.BusinessName, &item.UserID, &item.PartySize, &startsAt,
+		&item.Status, &item.Note, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.Reservation{}, err
+	}
+
+	item.StartsAt = startsAt.Format(time.RFC3339)
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -0,0 +1,400 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+// reviewColumns selects a review together with its owner reply, if any.
+const reviewColumns = `r.id, r.business_id, r.user_id, r.rating, r.text, r.attachments, r.status,
+	r.useful_count, r.funny_count, r.cool_count, r.created_at, r.updated_at,
+	rr.id, rr.user_id, rr.text, rr.created_at, rr.updated_at`
+
+type ReviewRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewReviewRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewRepo {
+	return &ReviewRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewRepo) Create(ctx context.Context, req entity.Review) (entity.Review, error) {
+	req.ID = uuid.NewString()
+	if req.Attachments == nil {
+		req.Attachments = []string{}
+	}
+
+	query, args, err := r.pg.Builder.Insert("reviews").
+		Columns(`id, business_id, user_id, rating, text, attachments, status, text_hash`).
+		Values(req.ID, req.BusinessID, req.UserID, req.Rating, req.Text, req.Attachments, req.Status, req.TextHash).ToSql()
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return req, nil
+}
+
+func (r *ReviewRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Review, error) {
+	query, args, err := r.pg.Builder.
+		Select(reviewColumns).
+		From("reviews r").
+		LeftJoin("review_replies rr ON rr.review_id = r.id").
+		Where("r.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return scanReview(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetList lists reviews with their business joined as b, so that filters can be on b.status.
+func (r *ReviewRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.ReviewList, error) {
+	response := entity.ReviewList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(reviewColumns).
+		From("reviews r").
+		Join("businesses b ON b.id = r.business_id").
+		LeftJoin("review_replies rr ON rr.review_id = r.id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanReview(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("reviews r").
+		Join("businesses b ON b.id = r.business_id").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func (r *ReviewRepo) Update(ctx context.Context, req entity.Review) (entity.Review, error) {
+	if req.Attachments == nil {
+		req.Attachments = []string{}
+	}
+
+	mp := map[string]interface{}{
+		"rating":      req.Rating,
+		"text":        req.Text,
+		"attachments": req.Attachments,
+		"text_hash":   req.TextHash,
+		"updated_at":  time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("reviews").SetMap(mp).Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	return req, nil
+}
+
+func (r *ReviewRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("reviews").Where("id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewRepo) UpdateField(ctx context.Context, req entity.UpdateFieldRequest) (entity.RowsEffected, error) {
+	mp := map[string]interface{}{}
+	response := entity.RowsEffected{}
+
+	for _, item := range req.Items {
+		mp[item.Column] = item.Value
+	}
+
+	query, args, err := r.pg.Builder.Update("reviews").SetMap(mp).Where(PrepareFilter(req.Filter)).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row rowScanner) (entity.Review, error) {
+	var (
+		item                            entity.Review
+		createdAt, updatedAt            time.Time
+		replyID, replyUserID, replyText sql.NullString
+		replyCreatedAt, replyUpdatedAt  sql.NullTime
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.UserID, &item.Rating, &item.Text, &item.Attachments, &item.Status,
+		&item.UsefulCount, &item.FunnyCount, &item.CoolCount, &createdAt, &updatedAt,
+		&replyID, &replyUserID, &replyText, &replyCreatedAt, &replyUpdatedAt)
+	if err != nil {
+		return entity.Review{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	if replyID.Valid {
+		item.Reply = &entity.ReviewReply{
+			ID:        replyID.String,
+			ReviewID:  item.ID,
+			UserID:    replyUserID.String,
+			Text:      replyText.String,
+			CreatedAt: replyCreatedAt.Time.Format(time.RFC3339),
+			UpdatedAt: replyUpdatedAt.Time.Format(time.RFC3339),
+		}
+	}
+
+	return item, nil
+}
+
+// ReviewReplyRepo stores the owner replies to reviews
+type ReviewReplyRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewReviewReplyRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewReplyRepo {
+	return &ReviewReplyRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewReplyRepo) Create(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("review_replies").
+		Columns(`id, review_id, user_id, text`).
+		Values(req.ID, req.ReviewID, req.UserID, req.Text).ToSql()
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	return req, nil
+}
+
+func (r *ReviewReplyRepo) GetSingle(ctx context.Context, req entity.ReviewReplySingleRequest) (entity.ReviewReply, error) {
+	var (
+		response             entity.ReviewReply
+		createdAt, updatedAt time.Time
+	)
+
+	queryBuilder := r.pg.Builder.
+		Select(`id, review_id, user_id, text, created_at, updated_at`).
+		From("review_replies")
+
+	switch {
+	case req.ID != "":
+		queryBuilder = queryBuilder.Where("id = ?", req.ID)
+	case req.ReviewID != "":
+		queryBuilder = queryBuilder.Where("review_id = ?", req.ReviewID)
+	default:
+		return entity.ReviewReply{}, fmt.Errorf("GetSingle - invalid request")
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, query, args...).
+		Scan(&response.ID, &response.ReviewID, &response.UserID, &response.Text, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	response.CreatedAt = createdAt.Format(time.RFC3339)
+	response.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+func (r *ReviewReplyRepo) Update(ctx context.Context, req entity.ReviewReply) (entity.ReviewReply, error) {
+	mp := map[string]interface{}{
+		"text":       req.Text,
+		"updated_at": time.Now().Format(time.RFC3339),
+	}
+
+	query, args, err := r.pg.Builder.Update("review_replies").SetMap(mp).Where("review_id = ?", req.ReviewID).ToSql()
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.ReviewReply{}, err
+	}
+
+	return req, nil
+}
+
+// Delete removes the reply to the given review.
+func (r *ReviewReplyRepo) Delete(ctx context.Context, req entity.Id) error {
+	query, args, err := r.pg.Builder.Delete("review_replies").Where("review_id = ?", req.ID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReviewVoteRepo stores useful, funny and cool votes. The counters on reviews are kept in sync by a trigger.
+type ReviewVoteRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewReviewVoteRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *ReviewVoteRepo {
+	return &ReviewVoteRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *ReviewVoteRepo) Create(ctx context.Context, req entity.ReviewVote) error {
+	query, args, err := r.pg.Builder.Insert("review_votes").
+		Columns(`review_id, user_id, vote_type`).
+		Values(req.ReviewID, req.UserID, req.VoteType).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReviewVoteRepo) Delete(ctx context.Context, req entity.ReviewVote) (entity.RowsEffected, error) {
+	response := entity.RowsEffected{}
+
+	query, args, err := r.pg.Builder.Delete("review_votes").
+		Where("review_id = ? AND user_id = ? AND vote_type = ?", req.ReviewID, req.UserID, req.VoteType).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+
+	response.RowsEffected = int(n.RowsAffected())
+
+	return response, nil
+}
+
+// GetList returns the votes a user cast on the given reviews.
+func (r *ReviewVoteRepo) GetList(ctx context.Context, userID string, reviewIDs []string) ([]entity.ReviewVote, error) {
+	var response []entity.ReviewVote
+
+	if userID == "" || len(reviewIDs) == 0 {
+		return response, nil
+	}
+
+	query, args, err := r.pg.Builder.
+		Select(`review_id, user_id, vote_type`).
+		From("review_votes").
+		Where("user_id = ? AND review_id = ANY(?)", userID, reviewIDs).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item entity.ReviewVote
+		err = rows.Scan(&item.ReviewID, &item.UserID, &item.VoteType)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, item)
+	}
+
+	return response, rows.Err()
+}
@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+// businessSnapshot builds an entity.BusinessSnapshot of the businesses row b.
+const businessSnapshot = `jsonb_build_object('name', b.business_name, 'location', b.location, 'category_ids', b.category_ids,
+	'description', b.description, 'contact_information', b.contact_information,
+	'attachments', b.attachments, 'price_level', b.price_level, 'attributes', b.attributes,
+	'opening_hours', b.opening_hours, 'closed_permanently', b.closed_permanently)`
+
+const businessRevisionColumns = `id, business_id, version, snapshot, COALESCE(author_id::text, ''), source, created_at`
+
+// recordRevision stores the business as it is in tx as its next revision. Callers update the businesses row
+// first in the same transaction, the row lock keeps concurrent writers from taking the same version.
+func recordRevision(ctx context.Context, pg *postgres.Postgres, tx pgx.Tx, businessID, authorID, source string) error {
+	query, args, err := pg.Builder.Insert("business_revisions").
+		Columns("id, business_id, version, snapshot, author_id, source").
+		Select(pg.Builder.
+			Select().
+			Column("?, b.id", uuid.NewString()).
+			Column("COALESCE((SELECT MAX(version) FROM business_revisions WHERE business_id = b.id), 0) + 1").
+			Column(businessSnapshot).
+			Column("?, ?", nullString(authorID), source).
+			From("businesses b").
+			Where("b.id = ?", businessID)).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+
+	return err
+}
+
+// BusinessRevisionRepo reads the revision history of businesses. Revisions are written by BusinessRepo and
+// EditSuggestionRepo in the transaction that changes the business.
+type BusinessRevisionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewBusinessRevisionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *BusinessRevisionRepo {
+	return &BusinessRevisionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *BusinessRevisionRepo) GetSingle(ctx context.Context, businessID string, version int) (entity.BusinessRevision, error) {
+	query, args, err := r.pg.Builder.
+		Select(businessRevisionColumns).
+		From("business_revisions").
+		Where("business_id = ? AND version = ?", businessID, version).ToSql()
+	if err != nil {
+		return entity.BusinessRevision{}, err
+	}
+
+	return scanBusinessRevision(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+func (r *BusinessRevisionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.BusinessRevisionList, error) {
+	response := entity.BusinessRevisionList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(businessRevisionColumns).
+		From("business_revisions")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanBusinessRevision(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("business_revisions").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+func scanBusinessRevision(row rowScanner) (entity.BusinessRevision, error) {
+	var (
+		item      entity.BusinessRevision
+		createdAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.Version, &item.Snapshot, &item.AuthorID, &item.Source, &createdAt)
+	if err != nil {
+		return entity.BusinessRevision{}, err
+	}
+
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -35,9 +35,17 @@ func (r *SessionRepo) Create(ctx context.Context, req entity.Session) (entity.Se
 		expireDate.Valid = true
 	}
 
+	lastActiveDate := sql.NullTime{}
+	lastActiveAt, err := time.Parse(time.RFC3339, req.LastActiveAt)
+	if err == nil {
+		lastActiveDate.Time = lastActiveAt
+		lastActiveDate.Valid = true
+	}
+
 	query, args, err := r.pg.Builder.Insert("session").
-		Columns(`id, user_id, ip_address, user_agent, is_active, expires_at, platform`).
-		Values(req.ID, req.UserID, req.IPAddress, req.UserAgent, req.IsActive, expireDate, req.Platform).ToSql()
+		Columns(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, browser, os, device_type, country, city`).
+		Values(req.ID, req.UserID, req.IPAddress, req.UserAgent, req.IsActive, expireDate, lastActiveDate, req.Platform,
+			req.Browser, req.OS, req.DeviceType, req.Country, req.City).ToSql()
 	if err != nil {
 		return entity.Session{}, err
 	}
@@ -57,7 +65,7 @@ func (r *SessionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Sess
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, created_at, updated_at`).
+		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, browser, os, device_type, country, city, created_at, updated_at`).
 		From("session").Where("id = ?", req.ID)
 
 	query, args, err := queryBuilder.ToSql()
@@ -67,7 +75,8 @@ func (r *SessionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.Sess
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
 		Scan(&response.ID, &response.UserID, &response.IPAddress, &response.UserAgent,
-			&response.IsActive, &expiresAt, &lastActiveAt, &response.Platform, &response.CreatedAt, &response.UpdatedAt)
+			&response.IsActive, &expiresAt, &lastActiveAt, &response.Platform, &response.Browser, &response.OS,
+			&response.DeviceType, &response.Country, &response.City, &response.CreatedAt, &response.UpdatedAt)
 	if err != nil {
 		return entity.Session{}, err
 	}
@@ -87,7 +96,7 @@ func (r *SessionRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 	var response entity.SessionList
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, created_at, updated_at`).
+		Select(`id, user_id, ip_address, user_agent, is_active, expires_at, last_active_at, platform, browser, os, device_type, country, city, created_at, updated_at`).
 		From("session")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -108,7 +117,8 @@ func (r *SessionRepo) GetList(ctx context.Context, req entity.GetListFilter) (en
 			item                    entity.Session
 		)
 		err = rows.Scan(&item.ID, &item.UserID, &item.IPAddress, &item.UserAgent,
-			&item.IsActive, &expiresAt, &lastActiveAt, &item.Platform, &item.CreatedAt, &item.UpdatedAt)
+			&item.IsActive, &expiresAt, &lastActiveAt, &item.Platform, &item.Browser, &item.OS,
+			&item.DeviceType, &item.Country, &item.City, &item.CreatedAt, &item.UpdatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -194,3 +204,22 @@ func (r *SessionRepo) UpdateField(ctx context.Context, req entity.UpdateFieldReq
 
 	return response, nil
 }
+
+// RememberDevice adds the browser, OS and device type of the session to the known devices of its user.
+// It returns true when the user had never signed in from that device before.
+func (r *SessionRepo) RememberDevice(ctx context.Context, req entity.Session) (bool, error) {
+	query, args, err := r.pg.Builder.Insert("known_devices").
+		Columns(`user_id, browser, os, device_type`).
+		Values(req.UserID, req.Browser, req.OS, req.DeviceType).
+		Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	n, err := r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	return n.RowsAffected() == 1, nil
+}
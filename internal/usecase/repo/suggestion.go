@@ -0,0 +1,226 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"yalp_ulab/config"
+	"yalp_ulab/internal/entity"
+	"yalp_ulab/pkg/logger"
+	"yalp_ulab/pkg/postgres"
+)
+
+const editSuggestionColumns = `s.id, s.business_id, b.business_name, s.user_id, s.edit, s.changes, s.note, s.status,
+	COALESCE(s.reviewed_by::text, ''), s.review_note, s.reviewed_at, s.created_at, s.updated_at`
+
+// EditSuggestionRepo stores corrections to businesses proposed by users.
+type EditSuggestionRepo struct {
+	pg     *postgres.Postgres
+	config *config.Config
+	logger *logger.Logger
+}
+
+func NewEditSuggestionRepo(pg *postgres.Postgres, config *config.Config, logger *logger.Logger) *EditSuggestionRepo {
+	return &EditSuggestionRepo{
+		pg:     pg,
+		config: config,
+		logger: logger,
+	}
+}
+
+func (r *EditSuggestionRepo) Create(ctx context.Context, req entity.EditSuggestion) (entity.EditSuggestion, error) {
+	req.ID = uuid.NewString()
+
+	query, args, err := r.pg.Builder.Insert("edit_suggestions").
+		Columns(`id, business_id, user_id, edit, changes, note, status`).
+		Values(req.ID, req.BusinessID, req.UserID, req.Edit, req.Changes, req.Note, entity.SuggestionStatusPending).ToSql()
+	if err != nil {
+		return entity.EditSuggestion{}, err
+	}
+
+	_, err = r.pg.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return entity.EditSuggestion{}, err
+	}
+
+	return r.GetSingle(ctx, entity.Id{ID: req.ID})
+}
+
+func (r *EditSuggestionRepo) GetSingle(ctx context.Context, req entity.Id) (entity.EditSuggestion, error) {
+	query, args, err := r.pg.Builder.
+		Select(editSuggestionColumns).
+		From("edit_suggestions s").
+		Join("businesses b ON b.id = s.business_id").
+		Where("s.id = ?", req.ID).ToSql()
+	if err != nil {
+		return entity.EditSuggestion{}, err
+	}
+
+	return scanEditSuggestion(r.pg.Pool.QueryRow(ctx, query, args...))
+}
+
+// GetList lists suggestions, filter on s. (edit_suggestions) columns.
+func (r *EditSuggestionRepo) GetList(ctx context.Context, req entity.GetListFilter) (entity.EditSuggestionList, error) {
+	response := entity.EditSuggestionList{}
+
+	queryBuilder := r.pg.Builder.
+		Select(editSuggestionColumns).
+		From("edit_suggestions s").
+		Join("businesses b ON b.id = s.business_id")
+
+	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return response, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanEditSuggestion(rows)
+		if err != nil {
+			return response, err
+		}
+
+		response.Items = append(response.Items, item)
+	}
+
+	countQuery, args, err := r.pg.Builder.Select("COUNT(1)").From("edit_suggestions s").Where(where).ToSql()
+	if err != nil {
+		return response, err
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, countQuery, args...).Scan(&response.Count)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// review moves a pending suggestion to the given status, entity.ErrSuggestionReviewed if it isn't pending anymore.
+func (r *EditSuggestionRepo) review(ctx context.Context, tx pgx.Tx, id, status, reviewerID, note string) error {
+	now := time.Now().Format(time.RFC3339)
+
+	query, args, err := r.pg.Builder.Update("edit_suggestions").
+		Set("status", status).
+		Set("reviewed_by", reviewerID).
+		Set("review_note", note).
+		Set("reviewed_at", now).
+		Set("updated_at", now).
+		Where("id = ? AND status = ?", id, entity.SuggestionStatusPending).ToSql()
+	if err != nil {
+		return err
+	}
+
+	n, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if n.RowsAffected() == 0 {
+		return entity.ErrSuggestionReviewed
+	}
+
+	return nil
+}
+
+// Approve marks the suggestion approved and applies its edit to the business in one transaction,
+// recording a revision authored by the user who made the suggestion.
+// textHash is the contentfilter.Fingerprint of the business with the edit applied.
+func (r *EditSuggestionRepo) Approve(ctx context.Context, req entity.EditSuggestion, reviewerID, note, textHash string) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.review(ctx, tx, req.ID, entity.SuggestionStatusApproved, reviewerID, note)
+	if err != nil {
+		return err
+	}
+
+	mp := map[string]interface{}{
+		"text_hash":  textHash,
+		"updated_at": time.Now().Format(time.RFC3339),
+	}
+
+	if req.Edit.Name != nil {
+		mp["business_name"] = *req.Edit.Name
+	}
+	if req.Edit.Location != nil {
+		mp["location"] = *req.Edit.Location
+	}
+	if req.Edit.ContactInformation != nil {
+		mp["contact_information"] = *req.Edit.ContactInformation
+	}
+	if req.Edit.OpeningHours != nil {
+		mp["opening_hours"] = *req.Edit.OpeningHours
+	}
+	if req.Edit.ClosedPermanently != nil {
+		mp["closed_permanently"] = *req.Edit.ClosedPermanently
+	}
+
+	query, args, err := r.pg.Builder.Update("businesses").SetMap(mp).Where("id = ?", req.BusinessID).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = recordRevision(ctx, r.pg, tx, req.BusinessID, req.UserID, entity.RevisionSourceSuggestion)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Reject marks the suggestion rejected, entity.ErrSuggestionReviewed if it isn't pending anymore.
+func (r *EditSuggestionRepo) Reject(ctx context.Context, id, reviewerID, note string) error {
+	tx, err := r.pg.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.review(ctx, tx, id, entity.SuggestionStatusRejected, reviewerID, note)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanEditSuggestion(row rowScanner) (entity.EditSuggestion, error) {
+	var (
+		item                 entity.EditSuggestion
+		reviewedAt           sql.NullTime
+		createdAt, updatedAt time.Time
+	)
+
+	err := row.Scan(&item.ID, &item.BusinessID, &item.BusinessName, &item.UserID, &item.Edit, &item.Changes, &item.Note, &item.Status,
+		&item.ReviewedBy, &item.ReviewNote, &reviewedAt, &createdAt, &updatedAt)
+	if err != nil {
+		return entity.EditSuggestion{}, err
+	}
+
+	if reviewedAt.Valid {
+		item.ReviewedAt = reviewedAt.Time.Format(time.RFC3339)
+	}
+	item.CreatedAt = createdAt.Format(time.RFC3339)
+	item.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+	return item, nil
+}
@@ -51,7 +51,7 @@ func (r *UserRepo) GetSingle(ctx context.Context, req entity.UserSingleRequest)
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, full_name, email, password, user_type, user_role, status, created_at, updated_at`).
+		Select(`id, full_name, email, password, user_type, user_role, status, COALESCE(block_reason, ''), created_at, updated_at`).
 		From("users")
 
 	switch {
@@ -70,7 +70,7 @@ func (r *UserRepo) GetSingle(ctx context.Context, req entity.UserSingleRequest)
 
 	err = r.pg.Pool.QueryRow(ctx, query, args...).
 		Scan(&response.ID, &response.FullName, &response.Email, &response.Password,
-			&response.UserType, &response.UserRole, &response.Status, &createdAt, &updatedAt)
+			&response.UserType, &response.UserRole, &response.Status, &response.BlockReason, &createdAt, &updatedAt)
 	if err != nil {
 		return entity.User{}, err
 	}
@@ -88,7 +88,7 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 	)
 
 	queryBuilder := r.pg.Builder.
-		Select(`id, full_name, email, password, user_type, user_role, status, created_at, updated_at`).
+		Select(`id, full_name, email, password, user_type, user_role, status, COALESCE(block_reason, ''), created_at, updated_at`).
 		From("users")
 
 	queryBuilder, where := PrepareGetListQuery(queryBuilder, req)
@@ -107,7 +107,7 @@ func (r *UserRepo) GetList(ctx context.Context, req entity.GetListFilter) (entit
 	for rows.Next() {
 		var item entity.User
 		err = rows.Scan(&item.ID, &item.FullName, &item.Email, &item.Password,
-			&item.UserType, &item.UserRole, &item.Status, &createdAt, &updatedAt)
+			&item.UserType, &item.UserRole, &item.Status, &item.BlockReason, &createdAt, &updatedAt)
 		if err != nil {
 			return response, err
 		}
@@ -137,6 +137,7 @@ func (r *UserRepo) Update(ctx context.Context, req entity.User) (entity.User, er
 		"status":     req.Status,
 		"email":      req.Email,
 		"user_role":  req.UserRole,
+		"user_type":  req.UserType,
 		"updated_at": time.Now().Format(time.RFC3339),
 	}
 
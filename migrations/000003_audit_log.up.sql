@@ -0,0 +1,26 @@
+CREATE TABLE audit_log (
+                           id uuid PRIMARY KEY,
+                           actor_id uuid,
+                           session_id uuid,
+                           ip_address varchar(64) NOT NULL DEFAULT '',
+                           action varchar(64) NOT NULL,
+                           target_type varchar(64) NOT NULL,
+                           target_id varchar(64) NOT NULL DEFAULT '',
+                           diff jsonb NOT NULL DEFAULT '{}',
+                           created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_log_actor_id_idx ON audit_log (actor_id);
+CREATE INDEX audit_log_target_idx ON audit_log (target_type, target_id);
+CREATE INDEX audit_log_created_at_idx ON audit_log (created_at);
+
+-- audit_log is append-only: rows can never be changed or removed.
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,5 @@
+ALTER TABLE session DROP COLUMN browser;
+ALTER TABLE session DROP COLUMN os;
+ALTER TABLE session DROP COLUMN device_type;
+ALTER TABLE session DROP COLUMN country;
+ALTER TABLE session DROP COLUMN city;
@@ -0,0 +1,5 @@
+ALTER TABLE session ADD COLUMN browser varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE session ADD COLUMN os varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE session ADD COLUMN device_type varchar(16) NOT NULL DEFAULT '';
+ALTER TABLE session ADD COLUMN country varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE session ADD COLUMN city varchar(128) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP TRIGGER casbin_rule_notify ON casbin_rule;
+DROP FUNCTION casbin_rule_notify;
+DROP TABLE casbin_rule;
@@ -0,0 +1,41 @@
+CREATE TABLE casbin_rule (
+                             id serial PRIMARY KEY,
+                             ptype varchar(8) NOT NULL,
+                             v0 varchar(255) NOT NULL DEFAULT '',
+                             v1 varchar(255) NOT NULL DEFAULT '',
+                             v2 varchar(255) NOT NULL DEFAULT '',
+                             v3 varchar(255) NOT NULL DEFAULT '',
+                             v4 varchar(255) NOT NULL DEFAULT '',
+                             v5 varchar(255) NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX casbin_rule_unique_idx ON casbin_rule (ptype, v0, v1, v2, v3, v4, v5);
+
+-- Policies previously shipped in config/policy.csv
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/swagger/*', 'GET'),
+    ('p', 'unauthorized', '/v1/auth/*', 'GET|POST'),
+    ('p', 'user', '/v1/user/*', 'PUT|DELETE'),
+    ('p', 'user', '/v1/user/:id', 'GET'),
+    ('p', 'admin', '/v1/user/*', 'GET|POST|PUT|DELETE'),
+    ('p', 'user', '/v1/session/*', 'GET|DELETE'),
+    ('p', 'user', '/v1/session/revoke-others', 'POST'),
+    ('p', 'admin', '/v1/session/*', 'GET|POST|PUT|DELETE'),
+    ('p', 'admin', '/v1/audit/*', 'GET'),
+    ('p', 'admin', '/v1/admin/*', 'GET|POST|PUT|DELETE');
+
+INSERT INTO casbin_rule (ptype, v0, v1) VALUES
+    ('g', 'user', 'unauthorized'),
+    ('g', 'admin', 'user');
+
+-- Every change is broadcast, so all app instances reload their policy.
+CREATE FUNCTION casbin_rule_notify() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('casbin_policy', TG_OP);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER casbin_rule_notify
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON casbin_rule
+    FOR EACH STATEMENT EXECUTE FUNCTION casbin_rule_notify();
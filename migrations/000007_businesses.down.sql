@@ -0,0 +1,3 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 LIKE '/v1/business/%';
+
+DROP TABLE businesses;
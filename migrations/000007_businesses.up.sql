@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS businesses (
+                            id uuid PRIMARY KEY,
+                            business_name varchar(255) NOT NULL,
+                            location jsonb NOT NULL DEFAULT '{}',
+                            category varchar(64) NOT NULL DEFAULT '',
+                            description text NOT NULL DEFAULT '',
+                            contact_information text NOT NULL DEFAULT '',
+                            attachments text[] NOT NULL DEFAULT '{}',
+                            created_by uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                            created_at timestamp NOT NULL DEFAULT now(),
+                            updated_at timestamp NOT NULL DEFAULT now(),
+                            deleted_at timestamp
+);
+
+CREATE INDEX IF NOT EXISTS businesses_created_by_idx ON businesses (created_by);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/business/list', 'GET'),
+    ('p', 'unauthorized', '/v1/business/:id', 'GET'),
+    ('p', 'user', '/v1/business/*', 'POST|PUT|DELETE');
@@ -0,0 +1,7 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND (v1 LIKE '/v1/review/%' OR v1 LIKE '/v1/report/%');
+
+DROP TABLE reports;
+DROP TABLE review_votes;
+DROP FUNCTION review_votes_count();
+DROP TABLE review_replies;
+DROP TABLE reviews;
@@ -0,0 +1,86 @@
+CREATE TABLE reviews (
+                         id uuid PRIMARY KEY,
+                         business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                         user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         rating smallint NOT NULL CHECK (rating BETWEEN 1 AND 5),
+                         text text NOT NULL DEFAULT '',
+                         attachments text[] NOT NULL DEFAULT '{}',
+                         status varchar(32) NOT NULL DEFAULT 'published',
+                         useful_count integer NOT NULL DEFAULT 0,
+                         funny_count integer NOT NULL DEFAULT 0,
+                         cool_count integer NOT NULL DEFAULT 0,
+                         created_at timestamp NOT NULL DEFAULT now(),
+                         updated_at timestamp NOT NULL DEFAULT now(),
+                         UNIQUE (business_id, user_id)
+);
+
+CREATE INDEX reviews_user_id_idx ON reviews (user_id);
+CREATE INDEX reviews_most_helpful_idx ON reviews (business_id, useful_count DESC, created_at DESC);
+
+-- one public reply per review, written by the business owner
+CREATE TABLE review_replies (
+                                id uuid PRIMARY KEY,
+                                review_id uuid NOT NULL UNIQUE REFERENCES reviews(id) ON DELETE CASCADE,
+                                user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                text text NOT NULL,
+                                created_at timestamp NOT NULL DEFAULT now(),
+                                updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE review_votes (
+                              review_id uuid NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+                              user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              vote_type varchar(16) NOT NULL CHECK (vote_type IN ('useful', 'funny', 'cool')),
+                              created_at timestamp NOT NULL DEFAULT now(),
+                              PRIMARY KEY (review_id, user_id, vote_type)
+);
+
+-- keep the vote counters on reviews in sync, they are what "most helpful" sorts by
+CREATE FUNCTION review_votes_count() RETURNS trigger AS $$
+DECLARE
+    vote review_votes;
+    delta integer;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        vote := NEW;
+        delta := 1;
+    ELSE
+        vote := OLD;
+        delta := -1;
+    END IF;
+
+    UPDATE reviews SET
+        useful_count = useful_count + CASE WHEN vote.vote_type = 'useful' THEN delta ELSE 0 END,
+        funny_count = funny_count + CASE WHEN vote.vote_type = 'funny' THEN delta ELSE 0 END,
+        cool_count = cool_count + CASE WHEN vote.vote_type = 'cool' THEN delta ELSE 0 END
+    WHERE id = vote.review_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER review_votes_count
+    AFTER INSERT OR DELETE ON review_votes
+    FOR EACH ROW EXECUTE FUNCTION review_votes_count();
+
+CREATE TABLE reports (
+                         id uuid PRIMARY KEY,
+                         target_type varchar(32) NOT NULL,
+                         target_id uuid NOT NULL,
+                         reporter_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         reason_code varchar(32) NOT NULL,
+                         details text NOT NULL DEFAULT '',
+                         status varchar(32) NOT NULL DEFAULT 'open',
+                         created_at timestamp NOT NULL DEFAULT now(),
+                         updated_at timestamp NOT NULL DEFAULT now(),
+                         UNIQUE (target_type, target_id, reporter_id)
+);
+
+CREATE INDEX reports_target_idx ON reports (target_type, target_id);
+CREATE INDEX reports_status_idx ON reports (status, created_at);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/review/list', 'GET'),
+    ('p', 'unauthorized', '/v1/review/:id', 'GET'),
+    ('p', 'user', '/v1/review/*', 'POST|PUT|DELETE'),
+    ('p', 'user', '/v1/report/', 'POST');
@@ -0,0 +1,8 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 LIKE '/v1/photo/%';
+
+DROP TABLE moderation_history;
+DROP FUNCTION moderation_history_append_only();
+ALTER TABLE reports DROP COLUMN case_id;
+DROP TABLE moderation_cases;
+DROP TABLE photos;
+ALTER TABLE businesses DROP COLUMN status;
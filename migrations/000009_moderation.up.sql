@@ -0,0 +1,65 @@
+ALTER TABLE businesses ADD COLUMN status varchar(32) NOT NULL DEFAULT 'published';
+
+CREATE TABLE photos (
+                        id uuid PRIMARY KEY,
+                        business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                        user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                        url varchar(1024) NOT NULL,
+                        caption varchar(500) NOT NULL DEFAULT '',
+                        status varchar(32) NOT NULL DEFAULT 'published',
+                        created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX photos_business_id_idx ON photos (business_id, created_at DESC);
+CREATE INDEX photos_user_id_idx ON photos (user_id);
+
+CREATE TABLE moderation_cases (
+                                  id uuid PRIMARY KEY,
+                                  target_type varchar(32) NOT NULL,
+                                  target_id uuid NOT NULL,
+                                  target_owner_id uuid REFERENCES users(id) ON DELETE SET NULL,
+                                  status varchar(32) NOT NULL DEFAULT 'open',
+                                  report_count integer NOT NULL DEFAULT 0,
+                                  assignee_id uuid REFERENCES users(id) ON DELETE SET NULL,
+                                  reason_code varchar(32) NOT NULL DEFAULT '',
+                                  action varchar(32) NOT NULL DEFAULT '',
+                                  note text NOT NULL DEFAULT '',
+                                  created_at timestamp NOT NULL DEFAULT now(),
+                                  updated_at timestamp NOT NULL DEFAULT now(),
+                                  resolved_at timestamp
+);
+
+-- a piece of content has at most one unresolved case, new reports are added to it
+CREATE UNIQUE INDEX moderation_cases_unresolved_idx ON moderation_cases (target_type, target_id)
+    WHERE status IN ('open', 'in_review');
+CREATE INDEX moderation_cases_status_idx ON moderation_cases (status, report_count DESC, created_at);
+
+ALTER TABLE reports ADD COLUMN case_id uuid REFERENCES moderation_cases(id) ON DELETE SET NULL;
+CREATE INDEX reports_case_id_idx ON reports (case_id);
+
+CREATE TABLE moderation_history (
+                                    id uuid PRIMARY KEY,
+                                    case_id uuid NOT NULL REFERENCES moderation_cases(id),
+                                    actor_id uuid,
+                                    action varchar(32) NOT NULL,
+                                    reason_code varchar(32) NOT NULL DEFAULT '',
+                                    note text NOT NULL DEFAULT '',
+                                    created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX moderation_history_case_id_idx ON moderation_history (case_id, created_at);
+
+-- moderation_history is append-only, like audit_log
+CREATE FUNCTION moderation_history_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'moderation_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER moderation_history_append_only
+    BEFORE UPDATE OR DELETE ON moderation_history
+    FOR EACH ROW EXECUTE FUNCTION moderation_history_append_only();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/photo/list', 'GET'),
+    ('p', 'user', '/v1/photo/*', 'POST|DELETE');
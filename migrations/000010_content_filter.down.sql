@@ -0,0 +1,6 @@
+DROP INDEX reviews_user_id_created_at_idx;
+DROP INDEX businesses_text_hash_idx;
+DROP INDEX reviews_text_hash_idx;
+
+ALTER TABLE businesses DROP COLUMN text_hash;
+ALTER TABLE reviews DROP COLUMN text_hash;
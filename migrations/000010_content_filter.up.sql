@@ -0,0 +1,9 @@
+-- fingerprint of the normalized text, to find the same text posted by different users
+ALTER TABLE reviews ADD COLUMN text_hash varchar(64) NOT NULL DEFAULT '';
+ALTER TABLE businesses ADD COLUMN text_hash varchar(64) NOT NULL DEFAULT '';
+
+CREATE INDEX reviews_text_hash_idx ON reviews (text_hash);
+CREATE INDEX businesses_text_hash_idx ON businesses (text_hash);
+
+-- daily review limit
+CREATE INDEX reviews_user_id_created_at_idx ON reviews (user_id, created_at);
@@ -0,0 +1,5 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 LIKE '/v1/user/collections/%';
+
+DROP TABLE collection_items;
+DROP FUNCTION collection_items_count();
+DROP TABLE collections;
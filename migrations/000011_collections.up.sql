@@ -0,0 +1,45 @@
+CREATE TABLE collections (
+                             id uuid PRIMARY KEY,
+                             user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                             name varchar(100) NOT NULL,
+                             description varchar(500) NOT NULL DEFAULT '',
+                             is_public boolean NOT NULL DEFAULT false,
+                             item_count integer NOT NULL DEFAULT 0,
+                             created_at timestamp NOT NULL DEFAULT now(),
+                             updated_at timestamp NOT NULL DEFAULT now(),
+                             UNIQUE (user_id, name)
+);
+
+CREATE TABLE collection_items (
+                                  collection_id uuid NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+                                  business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                                  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                  note varchar(500) NOT NULL DEFAULT '',
+                                  created_at timestamp NOT NULL DEFAULT now(),
+                                  PRIMARY KEY (collection_id, business_id)
+);
+
+-- user_id is the collection owner, it lets the bookmarked flag skip the join on collections
+CREATE INDEX collection_items_user_id_idx ON collection_items (user_id, business_id);
+
+-- keeps collections.item_count in sync with collection_items
+CREATE FUNCTION collection_items_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE collections SET item_count = item_count + 1 WHERE id = NEW.collection_id;
+    ELSE
+        UPDATE collections SET item_count = item_count - 1 WHERE id = OLD.collection_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER collection_items_count
+    AFTER INSERT OR DELETE ON collection_items
+    FOR EACH ROW EXECUTE FUNCTION collection_items_count();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/user/collections/list', 'GET'),
+    ('p', 'unauthorized', '/v1/user/collections/:id', 'GET'),
+    ('p', 'unauthorized', '/v1/user/collections/:id/items', 'GET'),
+    ('p', 'user', '/v1/user/collections/*', 'POST');
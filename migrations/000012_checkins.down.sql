@@ -0,0 +1,5 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/user/:id/checkins';
+
+DROP TABLE checkins;
+DROP FUNCTION checkins_count();
+ALTER TABLE businesses DROP COLUMN checkin_count;
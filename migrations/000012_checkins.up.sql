@@ -0,0 +1,31 @@
+ALTER TABLE businesses ADD COLUMN checkin_count integer NOT NULL DEFAULT 0;
+
+CREATE TABLE checkins (
+                          id uuid PRIMARY KEY,
+                          user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                          business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                          distance_meters double precision NOT NULL,
+                          created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX checkins_user_id_idx ON checkins (user_id, created_at DESC);
+CREATE INDEX checkins_business_id_idx ON checkins (business_id, created_at DESC);
+
+-- keeps businesses.checkin_count in sync with checkins
+CREATE FUNCTION checkins_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE businesses SET checkin_count = checkin_count + 1 WHERE id = NEW.business_id;
+    ELSE
+        UPDATE businesses SET checkin_count = checkin_count - 1 WHERE id = OLD.business_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER checkins_count
+    AFTER INSERT OR DELETE ON checkins
+    FOR EACH ROW EXECUTE FUNCTION checkins_count();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'user', '/v1/user/:id/checkins', 'GET');
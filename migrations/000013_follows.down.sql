@@ -0,0 +1,4 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND (v1 LIKE '/v1/user/:id/follow%' OR v1 = '/v1/feed/');
+
+DROP INDEX photos_user_id_created_at_idx;
+DROP TABLE follows;
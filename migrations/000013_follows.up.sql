@@ -0,0 +1,18 @@
+CREATE TABLE follows (
+                         follower_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         followee_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         created_at timestamp NOT NULL DEFAULT now(),
+                         PRIMARY KEY (follower_id, followee_id),
+                         CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX follows_followee_id_idx ON follows (followee_id, created_at DESC);
+
+-- the feed is read by user and time from each source
+CREATE INDEX photos_user_id_created_at_idx ON photos (user_id, created_at DESC);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/user/:id/followers', 'GET'),
+    ('p', 'unauthorized', '/v1/user/:id/following', 'GET'),
+    ('p', 'user', '/v1/user/:id/follow', 'POST'),
+    ('p', 'user', '/v1/feed/', 'GET');
@@ -0,0 +1,11 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND (v1 LIKE '/v1/profile/%' OR v1 = '/v1/user/:id/checkins');
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'user', '/v1/user/:id/checkins', 'GET');
+
+ALTER TABLE users
+    DROP COLUMN privacy,
+    DROP COLUMN city,
+    DROP COLUMN bio,
+    DROP COLUMN avatar_url,
+    DROP COLUMN display_name;
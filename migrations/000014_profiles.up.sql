@@ -0,0 +1,14 @@
+ALTER TABLE users
+    ADD COLUMN display_name varchar(100) NOT NULL DEFAULT '',
+    ADD COLUMN avatar_url varchar(1024) NOT NULL DEFAULT '',
+    ADD COLUMN bio varchar(500) NOT NULL DEFAULT '',
+    ADD COLUMN city varchar(100) NOT NULL DEFAULT '',
+    ADD COLUMN privacy jsonb NOT NULL DEFAULT '{"about": "public", "stats": "public", "checkins": "private"}';
+
+-- visit history is now visible to anyone the owner's privacy settings allow
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'user' AND v1 = '/v1/user/:id/checkins';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/user/:id/checkins', 'GET'),
+    ('p', 'unauthorized', '/v1/profile/:id', 'GET'),
+    ('p', 'user', '/v1/profile/', 'PUT');
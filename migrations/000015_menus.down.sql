@@ -0,0 +1,6 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'unauthorized' AND v1 IN ('/v1/business/:id/menus', '/v1/business/menus/:id');
+
+DROP TABLE menus;
+
+ALTER TABLE businesses
+    DROP COLUMN price_level;
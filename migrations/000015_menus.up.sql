@@ -0,0 +1,24 @@
+ALTER TABLE businesses
+    ADD COLUMN price_level smallint NOT NULL DEFAULT 0 CHECK (price_level BETWEEN 0 AND 4);
+
+CREATE INDEX businesses_price_level_idx ON businesses (price_level);
+
+-- sections and their items are always read and replaced with the menu, so they live in it
+CREATE TABLE menus (
+                       id uuid PRIMARY KEY,
+                       business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                       kind varchar(16) NOT NULL,
+                       name varchar(100) NOT NULL,
+                       description varchar(500) NOT NULL DEFAULT '',
+                       currency char(3) NOT NULL,
+                       position integer NOT NULL DEFAULT 0,
+                       sections jsonb NOT NULL DEFAULT '[]',
+                       created_at timestamp NOT NULL DEFAULT now(),
+                       updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX menus_business_id_idx ON menus (business_id, position);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/business/:id/menus', 'GET'),
+    ('p', 'unauthorized', '/v1/business/menus/:id', 'GET');
@@ -0,0 +1,6 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/attribute/list';
+
+ALTER TABLE businesses
+    DROP COLUMN attributes;
+
+DROP TABLE attributes;
@@ -0,0 +1,28 @@
+CREATE TABLE attributes (
+                            id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+                            key varchar(64) NOT NULL UNIQUE,
+                            name varchar(100) NOT NULL,
+                            description varchar(500) NOT NULL DEFAULT '',
+                            type varchar(16) NOT NULL,
+                            options text[] NOT NULL DEFAULT '{}',
+                            unit varchar(32) NOT NULL DEFAULT '',
+                            created_at timestamp NOT NULL DEFAULT now(),
+                            updated_at timestamp NOT NULL DEFAULT now()
+);
+
+-- values keyed by attributes.key, filtered with jsonpath predicates
+ALTER TABLE businesses
+    ADD COLUMN attributes jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX businesses_attributes_idx ON businesses USING gin (attributes jsonb_path_ops);
+
+INSERT INTO attributes (key, name, type, options) VALUES
+    ('wheelchair_accessible', 'Wheelchair accessible', 'bool', '{}'),
+    ('wifi', 'Wi-Fi', 'enum', '{free,paid,none}'),
+    ('outdoor_seating', 'Outdoor seating', 'bool', '{}'),
+    ('accepts_cards', 'Accepts credit cards', 'bool', '{}'),
+    ('parking', 'Parking', 'enum', '{street,lot,garage,valet,none}'),
+    ('kid_friendly', 'Good for kids', 'bool', '{}');
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/attribute/list', 'GET');
@@ -0,0 +1,12 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/category/tree';
+
+ALTER TABLE businesses
+    ADD COLUMN category varchar(64) NOT NULL DEFAULT '';
+
+-- the first category of a business, e.g. 'pizza' becomes 'Pizza'
+UPDATE businesses b SET category = initcap(c.slug) FROM categories c WHERE c.id = b.category_ids[1];
+
+ALTER TABLE businesses
+    DROP COLUMN category_ids;
+
+DROP TABLE categories;
@@ -0,0 +1,39 @@
+CREATE TABLE categories (
+                            id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+                            parent_id uuid REFERENCES categories(id),
+                            slug varchar(64) NOT NULL UNIQUE,
+                            names jsonb NOT NULL DEFAULT '{}',
+                            icon varchar(255) NOT NULL DEFAULT '',
+                            position integer NOT NULL DEFAULT 0,
+                            created_at timestamp NOT NULL DEFAULT now(),
+                            updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+-- the categories that used to be hardcoded
+INSERT INTO categories (slug, names, position) VALUES
+    ('restaurant', '{"en": "Restaurant"}', 0),
+    ('retail', '{"en": "Retail"}', 1),
+    ('service', '{"en": "Service"}', 2),
+    ('healthcare', '{"en": "Healthcare"}', 3),
+    ('entertainment', '{"en": "Entertainment"}', 4);
+
+INSERT INTO categories (parent_id, slug, names)
+SELECT id, 'italian', '{"en": "Italian"}' FROM categories WHERE slug = 'restaurant';
+
+INSERT INTO categories (parent_id, slug, names)
+SELECT id, 'pizza', '{"en": "Pizza"}' FROM categories WHERE slug = 'italian';
+
+ALTER TABLE businesses
+    ADD COLUMN category_ids uuid[] NOT NULL DEFAULT '{}';
+
+UPDATE businesses b SET category_ids = ARRAY[c.id] FROM categories c WHERE c.slug = lower(b.category);
+
+ALTER TABLE businesses
+    DROP COLUMN category;
+
+CREATE INDEX businesses_category_ids_idx ON businesses USING gin (category_ids);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/category/tree', 'GET');
@@ -0,0 +1,11 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND (v1 LIKE '/v1/reservation/%' OR v1 IN (
+    '/v1/business/:id/reservation-settings',
+    '/v1/business/:id/availability',
+    '/v1/business/:id/calendar'
+));
+
+DROP TABLE reservations;
+DROP TABLE reservation_settings;
+
+ALTER TABLE businesses
+    DROP COLUMN opening_hours;
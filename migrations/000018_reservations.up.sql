@@ -0,0 +1,35 @@
+ALTER TABLE businesses
+    ADD COLUMN opening_hours jsonb NOT NULL DEFAULT '{"timezone": "UTC", "periods": []}';
+
+CREATE TABLE reservation_settings (
+                                      business_id uuid PRIMARY KEY REFERENCES businesses(id) ON DELETE CASCADE,
+                                      enabled boolean NOT NULL DEFAULT false,
+                                      slot_minutes integer NOT NULL DEFAULT 30,
+                                      capacity integer NOT NULL DEFAULT 20,
+                                      max_party_size integer NOT NULL DEFAULT 8,
+                                      min_notice_minutes integer NOT NULL DEFAULT 60,
+                                      max_days_ahead integer NOT NULL DEFAULT 60,
+                                      updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE reservations (
+                              id uuid PRIMARY KEY,
+                              business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                              user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              party_size integer NOT NULL CHECK (party_size > 0),
+                              starts_at timestamp NOT NULL,
+                              status varchar(16) NOT NULL DEFAULT 'confirmed',
+                              note varchar(500) NOT NULL DEFAULT '',
+                              created_at timestamp NOT NULL DEFAULT now(),
+                              updated_at timestamp NOT NULL DEFAULT now()
+);
+
+-- slot capacity is checked under an advisory lock on the business, this stops the same user booking a slot twice
+CREATE UNIQUE INDEX reservations_user_slot_idx ON reservations (user_id, business_id, starts_at) WHERE status = 'confirmed';
+CREATE INDEX reservations_business_id_idx ON reservations (business_id, starts_at);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/business/:id/reservation-settings', 'GET'),
+    ('p', 'unauthorized', '/v1/business/:id/availability', 'GET'),
+    ('p', 'user', '/v1/business/:id/calendar', 'GET'),
+    ('p', 'user', '/v1/reservation/*', 'GET|POST|PUT');
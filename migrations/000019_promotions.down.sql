@@ -0,0 +1,3 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'unauthorized' AND v1 IN ('/v1/business/:id/promotions', '/v1/business/promotions/:id', '/v1/promotion/list');
+
+DROP TABLE promotions;
@@ -0,0 +1,21 @@
+-- promotions are never deleted when they end, listings leave out the ones whose ends_at has passed
+CREATE TABLE promotions (
+                            id uuid PRIMARY KEY,
+                            business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                            kind varchar(16) NOT NULL,
+                            title varchar(100) NOT NULL,
+                            description varchar(1000) NOT NULL DEFAULT '',
+                            weekdays integer[] NOT NULL DEFAULT '{}',
+                            starts_at timestamp NOT NULL,
+                            ends_at timestamp NOT NULL CHECK (ends_at > starts_at),
+                            created_at timestamp NOT NULL DEFAULT now(),
+                            updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX promotions_business_id_idx ON promotions (business_id, ends_at);
+CREATE INDEX promotions_ends_at_idx ON promotions (ends_at, starts_at);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/business/:id/promotions', 'GET'),
+    ('p', 'unauthorized', '/v1/business/promotions/:id', 'GET'),
+    ('p', 'unauthorized', '/v1/promotion/list', 'GET');
@@ -0,0 +1,13 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 LIKE '/v1/question/%';
+
+DROP TABLE answer_votes;
+DROP TABLE question_votes;
+DROP FUNCTION answer_votes_count();
+DROP FUNCTION question_votes_count();
+
+ALTER TABLE questions
+    DROP CONSTRAINT questions_accepted_answer_id_fkey;
+
+DROP TABLE answers;
+DROP FUNCTION answers_count();
+DROP TABLE questions;
@@ -0,0 +1,97 @@
+CREATE TABLE questions (
+                           id uuid PRIMARY KEY,
+                           business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                           user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                           text varchar(1000) NOT NULL,
+                           answer_count integer NOT NULL DEFAULT 0,
+                           upvote_count integer NOT NULL DEFAULT 0,
+                           accepted_answer_id uuid,
+                           created_at timestamp NOT NULL DEFAULT now(),
+                           updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX questions_business_id_idx ON questions (business_id, upvote_count DESC, created_at DESC);
+
+CREATE TABLE answers (
+                         id uuid PRIMARY KEY,
+                         question_id uuid NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+                         user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                         text varchar(2000) NOT NULL,
+                         upvote_count integer NOT NULL DEFAULT 0,
+                         created_at timestamp NOT NULL DEFAULT now(),
+                         updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX answers_question_id_idx ON answers (question_id, upvote_count DESC, created_at);
+
+ALTER TABLE questions
+    ADD CONSTRAINT questions_accepted_answer_id_fkey FOREIGN KEY (accepted_answer_id) REFERENCES answers(id) ON DELETE SET NULL;
+
+CREATE TABLE question_votes (
+                                question_id uuid NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+                                user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                created_at timestamp NOT NULL DEFAULT now(),
+                                PRIMARY KEY (question_id, user_id)
+);
+
+CREATE TABLE answer_votes (
+                              answer_id uuid NOT NULL REFERENCES answers(id) ON DELETE CASCADE,
+                              user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              created_at timestamp NOT NULL DEFAULT now(),
+                              PRIMARY KEY (answer_id, user_id)
+);
+
+-- keep the counters on questions and answers in sync, questions and answers are sorted by them
+CREATE FUNCTION answers_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE questions SET answer_count = answer_count + 1 WHERE id = NEW.question_id;
+    ELSE
+        UPDATE questions SET answer_count = answer_count - 1 WHERE id = OLD.question_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER answers_count
+    AFTER INSERT OR DELETE ON answers
+    FOR EACH ROW EXECUTE FUNCTION answers_count();
+
+CREATE FUNCTION question_votes_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE questions SET upvote_count = upvote_count + 1 WHERE id = NEW.question_id;
+    ELSE
+        UPDATE questions SET upvote_count = upvote_count - 1 WHERE id = OLD.question_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER question_votes_count
+    AFTER INSERT OR DELETE ON question_votes
+    FOR EACH ROW EXECUTE FUNCTION question_votes_count();
+
+CREATE FUNCTION answer_votes_count() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE answers SET upvote_count = upvote_count + 1 WHERE id = NEW.answer_id;
+    ELSE
+        UPDATE answers SET upvote_count = upvote_count - 1 WHERE id = OLD.answer_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER answer_votes_count
+    AFTER INSERT OR DELETE ON answer_votes
+    FOR EACH ROW EXECUTE FUNCTION answer_votes_count();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'unauthorized', '/v1/question/list', 'GET'),
+    ('p', 'unauthorized', '/v1/question/:id', 'GET'),
+    ('p', 'unauthorized', '/v1/question/:id/answers', 'GET'),
+    ('p', 'user', '/v1/question/*', 'POST|DELETE');
@@ -0,0 +1,4 @@
+DROP INDEX businesses_name_trgm_idx;
+
+ALTER TABLE businesses
+    DROP COLUMN merged_into;
@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- duplicate detection compares the names of nearby businesses by trigram similarity
+CREATE INDEX businesses_name_trgm_idx ON businesses USING gin (business_name gin_trgm_ops);
+
+-- a merged business is hidden and GET /v1/business/:id redirects to the one it was merged into
+ALTER TABLE businesses
+    ADD COLUMN merged_into uuid REFERENCES businesses(id) ON DELETE SET NULL;
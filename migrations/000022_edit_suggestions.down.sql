@@ -0,0 +1,9 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN (
+    '/v1/business/:id/suggestions',
+    '/v1/business/suggestions/:id'
+);
+
+DROP TABLE edit_suggestions;
+
+ALTER TABLE businesses
+    DROP COLUMN closed_permanently;
@@ -0,0 +1,23 @@
+ALTER TABLE businesses
+    ADD COLUMN closed_permanently boolean NOT NULL DEFAULT false;
+
+CREATE TABLE edit_suggestions (
+                                  id uuid PRIMARY KEY,
+                                  business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                                  user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                  edit jsonb NOT NULL,
+                                  changes jsonb NOT NULL,
+                                  note varchar(1000) NOT NULL DEFAULT '',
+                                  status varchar(20) NOT NULL DEFAULT 'pending',
+                                  reviewed_by uuid REFERENCES users(id) ON DELETE SET NULL,
+                                  review_note varchar(1000) NOT NULL DEFAULT '',
+                                  reviewed_at timestamp,
+                                  created_at timestamp NOT NULL DEFAULT now(),
+                                  updated_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX edit_suggestions_business_id_idx ON edit_suggestions (business_id, status, created_at DESC);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'user', '/v1/business/:id/suggestions', 'GET'),
+    ('p', 'user', '/v1/business/suggestions/:id', 'GET');
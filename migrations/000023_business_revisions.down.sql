@@ -0,0 +1,6 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN (
+    '/v1/business/:id/revisions',
+    '/v1/business/:id/revisions/diff'
+);
+
+DROP TABLE business_revisions;
@@ -0,0 +1,24 @@
+CREATE TABLE business_revisions (
+                                    id uuid PRIMARY KEY,
+                                    business_id uuid NOT NULL REFERENCES businesses(id) ON DELETE CASCADE,
+                                    version integer NOT NULL,
+                                    snapshot jsonb NOT NULL,
+                                    author_id uuid REFERENCES users(id) ON DELETE SET NULL,
+                                    source varchar(20) NOT NULL,
+                                    created_at timestamp NOT NULL DEFAULT now(),
+                                    UNIQUE (business_id, version)
+);
+
+-- the current content of existing businesses is their first revision
+INSERT INTO business_revisions (id, business_id, version, snapshot, author_id, source, created_at)
+SELECT gen_random_uuid(), b.id, 1,
+       jsonb_build_object('name', b.business_name, 'location', b.location, 'category_ids', b.category_ids,
+                          'description', b.description, 'contact_information', b.contact_information,
+                          'attachments', b.attachments, 'price_level', b.price_level, 'attributes', b.attributes,
+                          'opening_hours', b.opening_hours, 'closed_permanently', b.closed_permanently),
+       b.created_by, 'create', b.updated_at
+FROM businesses b;
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2) VALUES
+    ('p', 'user', '/v1/business/:id/revisions', 'GET'),
+    ('p', 'user', '/v1/business/:id/revisions/diff', 'GET');
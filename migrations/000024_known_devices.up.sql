@@ -0,0 +1,13 @@
+-- devices a user has signed in from, kept after their sessions are deleted on logout
+CREATE TABLE known_devices (
+                               user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               browser varchar(64) NOT NULL,
+                               os varchar(64) NOT NULL,
+                               device_type varchar(16) NOT NULL,
+                               first_seen_at timestamp NOT NULL DEFAULT now(),
+                               PRIMARY KEY (user_id, browser, os, device_type)
+);
+
+INSERT INTO known_devices (user_id, browser, os, device_type, first_seen_at)
+SELECT user_id, browser, os, device_type, MIN(created_at) FROM session
+GROUP BY user_id, browser, os, device_type;
@@ -0,0 +1,89 @@
+// Package contentfilter screens user-generated text before it is published.
+//
+// A Pipeline runs a list of filters over a piece of content and collects the flags they raise.
+// Filters are independent, so each kind of content gets the pipeline that fits it.
+package contentfilter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Content is the text being screened, with what filters need to know about its author.
+type Content struct {
+	ID       string // empty when the content is being created
+	AuthorID string
+	Text     string
+}
+
+// Flag is raised by a filter that found a problem with the content.
+type Flag struct {
+	Filter string `json:"filter"`
+	Detail string `json:"detail"`
+}
+
+// Filter checks content and returns the flags it raises, if any.
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, content Content) ([]Flag, error)
+}
+
+// Result of screening content through a Pipeline.
+type Result struct {
+	Flags []Flag `json:"flags"`
+}
+
+// Flagged reports whether any filter raised a flag.
+func (r Result) Flagged() bool {
+	return len(r.Flags) > 0
+}
+
+func (r Result) String() string {
+	parts := make([]string, 0, len(r.Flags))
+	for _, flag := range r.Flags {
+		parts = append(parts, flag.Filter+": "+flag.Detail)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Pipeline runs its filters in order.
+type Pipeline struct {
+	filters []Filter
+}
+
+func New(filters ...Filter) *Pipeline {
+	return &Pipeline{filters: filters}
+}
+
+// Screen runs every filter over the content. It stops at the first filter that fails to run.
+func (p *Pipeline) Screen(ctx context.Context, content Content) (Result, error) {
+	var result Result
+
+	for _, filter := range p.filters {
+		flags, err := filter.Check(ctx, content)
+		if err != nil {
+			return result, fmt.Errorf("contentfilter - %s: %w", filter.Name(), err)
+		}
+		result.Flags = append(result.Flags, flags...)
+	}
+
+	return result, nil
+}
+
+// Normalize lowercases text and turns everything but letters and digits into single spaces,
+// so that "Great  FOOD!!" and "great food" compare equal.
+func Normalize(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// Fingerprint is the hash of the normalized text, stored next to the content to find copies of it.
+func Fingerprint(text string) string {
+	sum := sha256.Sum256([]byte(Normalize(text)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+package contentfilter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func countOf(n int) Counter {
+	return func(ctx context.Context, content Content) (int, error) {
+		return n, nil
+	}
+}
+
+func TestFilters(t *testing.T) {
+	longText := "The pasta was cold and the waiter ignored us for an hour."
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		content Content
+		flagged bool
+	}{
+		{"blocked word", NewWordFilter(Profanity), Content{Text: "What a SHIT show!"}, true},
+		{"blocked phrase", NewWordFilter([]string{"free money"}), Content{Text: "Get free   money here"}, true},
+		{"word inside another word", NewWordFilter(Profanity), Content{Text: "Best fish in Scunthorpe"}, false},
+		{"url", LinkFilter{}, Content{Text: "See https://example.com/deal"}, true},
+		{"bare domain", LinkFilter{}, Content{Text: "order at cheapfood.com today"}, true},
+		{"no link", LinkFilter{}, Content{Text: "Great food. Will come back."}, false},
+		{"phone number", PhoneFilter{}, Content{Text: "Call +998 (90) 123-45-67 for discounts"}, true},
+		{"price", PhoneFilter{}, Content{Text: "Dinner for two was 250 000 sum"}, false},
+		{"date", PhoneFilter{}, Content{Text: "Visited on 2024-05-01"}, false},
+		{"duplicate", &DuplicateFilter{CountCopies: countOf(2)}, Content{Text: longText}, true},
+		{"short duplicate", &DuplicateFilter{CountCopies: countOf(2)}, Content{Text: "Great place!"}, false},
+		{"original", &DuplicateFilter{CountCopies: countOf(0)}, Content{Text: longText}, false},
+		{"over the limit", &RateLimitFilter{CountRecent: countOf(10), Limit: 10}, Content{Text: "ok"}, true},
+		{"under the limit", &RateLimitFilter{CountRecent: countOf(9), Limit: 10}, Content{Text: "ok"}, false},
+		{"edit over the limit", &RateLimitFilter{CountRecent: countOf(10), Limit: 10}, Content{ID: "1", Text: "ok"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(tt.filter).Screen(context.Background(), tt.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Flagged() != tt.flagged {
+				t.Fatalf("expected flagged=%v, got %+v", tt.flagged, result.Flags)
+			}
+		})
+	}
+}
+
+func TestPipeline_CollectsFlagsOfAllFilters(t *testing.T) {
+	pipeline := New(NewWordFilter(Profanity), LinkFilter{}, PhoneFilter{})
+
+	result, err := pipeline.Screen(context.Background(), Content{Text: "shit food, go to www.other.place instead"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Flags) != 2 || result.Flags[0].Filter != FilterWords || result.Flags[1].Filter != FilterLinks {
+		t.Fatalf("unexpected flags %+v", result.Flags)
+	}
+}
+
+func TestPipeline_StopsOnError(t *testing.T) {
+	failure := errors.New("db is down")
+
+	pipeline := New(&RateLimitFilter{
+		Limit: 1,
+		CountRecent: func(ctx context.Context, content Content) (int, error) {
+			return 0, failure
+		},
+	})
+
+	_, err := pipeline.Screen(context.Background(), Content{Text: "ok"})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected %v, got %v", failure, err)
+	}
+}
+
+func TestFingerprint_IgnoresCaseAndPunctuation(t *testing.T) {
+	if Fingerprint("Great FOOD!!  Friendly staff.") != Fingerprint("great food friendly staff") {
+		t.Fatal("expected equal fingerprints")
+	}
+	if Fingerprint("great food") == Fingerprint("great mood") {
+		t.Fatal("expected different fingerprints")
+	}
+}
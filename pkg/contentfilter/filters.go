@@ -0,0 +1,172 @@
+package contentfilter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Filter names, as they appear in flags
+const (
+	FilterWords     = "words"
+	FilterLinks     = "links"
+	FilterPhones    = "phones"
+	FilterDuplicate = "duplicate"
+	FilterRateLimit = "rate_limit"
+)
+
+const (
+	minPhoneDigits   = 9
+	maxPhoneDigits   = 15
+	minDuplicateText = 30
+)
+
+// Profanity is the built-in list of the word filter, extended by the configured blocklist.
+var Profanity = []string{
+	"asshole", "bastard", "bitch", "bullshit", "cunt", "dickhead", "fuck", "fucked", "fucker",
+	"fucking", "motherfucker", "shit", "shitty", "slut", "whore",
+}
+
+// WordFilter flags content containing a blocked word or phrase. Matching is on whole words of the
+// normalized text, so "Scunthorpe" is not caught by "cunt".
+type WordFilter struct {
+	phrases []string
+}
+
+func NewWordFilter(words ...[]string) *WordFilter {
+	f := &WordFilter{}
+	for _, list := range words {
+		for _, word := range list {
+			if phrase := Normalize(word); phrase != "" {
+				f.phrases = append(f.phrases, " "+phrase+" ")
+			}
+		}
+	}
+	return f
+}
+
+func (f *WordFilter) Name() string {
+	return FilterWords
+}
+
+func (f *WordFilter) Check(ctx context.Context, content Content) ([]Flag, error) {
+	text := " " + Normalize(content.Text) + " "
+
+	var found []string
+	for _, phrase := range f.phrases {
+		if strings.Contains(text, phrase) {
+			found = append(found, strings.TrimSpace(phrase))
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	return []Flag{{Filter: FilterWords, Detail: "blocked words: " + strings.Join(found, ", ")}}, nil
+}
+
+var linkRegexp = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9][a-z0-9-]*\.(?:com|net|org|info|biz|io|co|me|ly|ru|uz)\b(?:/\S*)?`)
+
+// LinkFilter flags content containing URLs or bare domain names.
+type LinkFilter struct{}
+
+func (LinkFilter) Name() string {
+	return FilterLinks
+}
+
+func (LinkFilter) Check(ctx context.Context, content Content) ([]Flag, error) {
+	links := linkRegexp.FindAllString(content.Text, -1)
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	return []Flag{{Filter: FilterLinks, Detail: "links: " + strings.Join(links, ", ")}}, nil
+}
+
+var phoneRegexp = regexp.MustCompile(`\+?\d[\d\s().-]{6,}\d`)
+
+// PhoneFilter flags content containing phone numbers. A number needs minPhoneDigits digits, which
+// keeps prices and dates out.
+type PhoneFilter struct{}
+
+func (PhoneFilter) Name() string {
+	return FilterPhones
+}
+
+func (PhoneFilter) Check(ctx context.Context, content Content) ([]Flag, error) {
+	var phones []string
+
+	for _, candidate := range phoneRegexp.FindAllString(content.Text, -1) {
+		digits := 0
+		for _, r := range candidate {
+			if unicode.IsDigit(r) {
+				digits++
+			}
+		}
+		if digits >= minPhoneDigits && digits <= maxPhoneDigits {
+			phones = append(phones, strings.TrimSpace(candidate))
+		}
+	}
+
+	if len(phones) == 0 {
+		return nil, nil
+	}
+
+	return []Flag{{Filter: FilterPhones, Detail: "phone numbers: " + strings.Join(phones, ", ")}}, nil
+}
+
+// Counter counts stored content related to the content being screened.
+type Counter func(ctx context.Context, content Content) (int, error)
+
+// DuplicateFilter flags text other users already posted. Short texts like "Great place!" are
+// legitimately common and are not checked.
+type DuplicateFilter struct {
+	// CountCopies counts content of other authors with the same Fingerprint.
+	CountCopies Counter
+}
+
+func (f *DuplicateFilter) Name() string {
+	return FilterDuplicate
+}
+
+func (f *DuplicateFilter) Check(ctx context.Context, content Content) ([]Flag, error) {
+	if len([]rune(Normalize(content.Text))) < minDuplicateText {
+		return nil, nil
+	}
+
+	copies, err := f.CountCopies(ctx, content)
+	if err != nil || copies == 0 {
+		return nil, err
+	}
+
+	return []Flag{{Filter: FilterDuplicate, Detail: fmt.Sprintf("same text posted %d times by other users", copies)}}, nil
+}
+
+// RateLimitFilter flags new content of authors who already posted Limit items in the window the counter covers.
+// Over the limit, content is held for review rather than rejected, so a legitimate burst is not lost.
+// Edits don't count against the limit.
+type RateLimitFilter struct {
+	// CountRecent counts the author's content in the window, not including the content being screened.
+	CountRecent Counter
+	Limit       int
+}
+
+func (f *RateLimitFilter) Name() string {
+	return FilterRateLimit
+}
+
+func (f *RateLimitFilter) Check(ctx context.Context, content Content) ([]Flag, error) {
+	if f.Limit <= 0 || content.ID != "" {
+		return nil, nil
+	}
+
+	count, err := f.CountRecent(ctx, content)
+	if err != nil || count < f.Limit {
+		return nil, err
+	}
+
+	return []Flag{{Filter: FilterRateLimit, Detail: fmt.Sprintf("limit of %d reached", f.Limit)}}, nil
+}
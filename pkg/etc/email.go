@@ -36,15 +36,55 @@ func GenerateOtpEmailBody(otp string) (string, error) {
 	return builder.String(), nil
 }
 
+type NewDevice struct {
+	Browser    string
+	OS         string
+	DeviceType string
+	IPAddress  string
+	Location   string
+	Time       string
+}
+
+// GenerateNewDeviceEmailBody generates the HTML email body for a sign-in from a new device
+func GenerateNewDeviceEmailBody(device NewDevice) (string, error) {
+	templateString := `
+<!DOCTYPE html>
+<html>
+<body>
+    <p>Your YALP account was just signed in from a new device.</p>
+    <ul>
+        <li>Device: {{.Browser}} on {{.OS}} ({{.DeviceType}})</li>
+        <li>IP address: {{.IPAddress}}</li>
+        {{if .Location}}<li>Location: {{.Location}}</li>{{end}}
+        <li>Time: {{.Time}}</li>
+    </ul>
+    <p>If this wasn't you, revoke the session and change your password.</p>
+</body>
+</html>
+`
+	tmpl, err := template.New("email").Parse(templateString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, device)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
 // sendEmail sends an email using SMTP
-func SendEmail(smtpHost, smtpPort, from, password, to, body string) error {
+func SendEmail(smtpHost, smtpPort, from, password, to, subject, body string) error {
 	auth := smtp.PlainAuth("", from, password, smtpHost)
 
-	msg := []byte(fmt.Sprintf("Subject: Otp code Mini twitter\r\n"+
+	msg := []byte(fmt.Sprintf("Subject: %s\r\n"+
 		"Content-Type: text/html; charset=\"UTF-8\"\r\n"+
 		"From: %s\r\n"+
 		"To: %s\r\n"+
-		"\r\n%s", from, to, body))
+		"\r\n%s", subject, from, to, body))
 
 	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{to}, msg)
 	if err != nil {
@@ -53,3 +93,105 @@ func SendEmail(smtpHost, smtpPort, from, password, to, body string) error {
 
 	return nil
 }
+
+type ModerationWarning struct {
+	ContentType string
+	Reason      string
+	Note        string
+}
+
+// GenerateModerationWarningEmailBody generates the HTML email body warning a user about content that broke the rules
+func GenerateModerationWarningEmailBody(warning ModerationWarning) (string, error) {
+	templateString := `
+<!DOCTYPE html>
+<html>
+<body>
+    <p>A {{.ContentType}} you posted on YALP was reported and reviewed by our moderators.</p>
+    <p>Reason: {{.Reason}}</p>
+    {{if .Note}}<p>{{.Note}}</p>{{end}}
+    <p>Please follow the community guidelines. Repeated violations may get your account blocked.</p>
+</body>
+</html>
+`
+	tmpl, err := template.New("email").Parse(templateString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, warning)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+type ReservationNotice struct {
+	BusinessName string
+	StartsAt     string // in the business timezone, e.g. "Wed, 01 May 2024 19:30 +05"
+	PartySize    int
+	Status       string // confirmed, rescheduled or cancelled
+}
+
+// GenerateReservationEmailBody generates the HTML email body telling a user about a change to their reservation
+func GenerateReservationEmailBody(notice ReservationNotice) (string, error) {
+	templateString := `
+<!DOCTYPE html>
+<html>
+<body>
+    <p>Your reservation at {{html .BusinessName}} is {{.Status}}.</p>
+    <ul>
+        <li>Time: {{.StartsAt}}</li>
+        <li>Party size: {{.PartySize}}</li>
+    </ul>
+    <p>You can reschedule or cancel it from your reservations on YALP.</p>
+</body>
+</html>
+`
+	tmpl, err := template.New("email").Parse(templateString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, notice)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+type AnswerNotice struct {
+	BusinessName string
+	Question     string
+	Answer       string
+	ByOwner      bool
+}
+
+// GenerateAnswerEmailBody generates the HTML email body telling a user their question about a business was answered
+func GenerateAnswerEmailBody(notice AnswerNotice) (string, error) {
+	templateString := `
+<!DOCTYPE html>
+<html>
+<body>
+    <p>{{if .ByOwner}}The owner of {{html .BusinessName}}{{else}}Someone{{end}} answered your question about {{html .BusinessName}} on YALP.</p>
+    <p>You asked: {{html .Question}}</p>
+    <p>Answer: {{html .Answer}}</p>
+</body>
+</html>
+`
+	tmpl, err := template.New("email").Parse(templateString)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, notice)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	return builder.String(), nil
+}
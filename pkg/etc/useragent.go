@@ -0,0 +1,87 @@
+package etc
+
+import "strings"
+
+// Device types
+const (
+	DeviceTypeDesktop = "desktop"
+	DeviceTypeMobile  = "mobile"
+	DeviceTypeTablet  = "tablet"
+	DeviceTypeBot     = "bot"
+	DeviceTypeOther   = "other"
+)
+
+type DeviceInfo struct {
+	Browser    string
+	OS         string
+	DeviceType string
+}
+
+type uaRule struct {
+	tokens []string
+	name   string
+}
+
+// Order matters: many browsers also advertise the tokens of the ones they are based on.
+var (
+	browserRules = []uaRule{
+		{[]string{"Edg/", "Edge/", "EdgiOS/", "EdgA/"}, "Edge"},
+		{[]string{"OPR/", "Opera"}, "Opera"},
+		{[]string{"SamsungBrowser/"}, "Samsung Internet"},
+		{[]string{"YaBrowser/"}, "Yandex Browser"},
+		{[]string{"Firefox/", "FxiOS/"}, "Firefox"},
+		{[]string{"CriOS/", "Chrome/", "Chromium/"}, "Chrome"},
+		{[]string{"Safari/"}, "Safari"},
+		{[]string{"okhttp/"}, "OkHttp"},
+		{[]string{"Dart/"}, "Dart"},
+		{[]string{"PostmanRuntime/"}, "Postman"},
+		{[]string{"curl/"}, "curl"},
+	}
+
+	osRules = []uaRule{
+		{[]string{"Windows"}, "Windows"},
+		{[]string{"iPhone", "iPad", "iPod"}, "iOS"},
+		{[]string{"Android"}, "Android"},
+		{[]string{"CrOS"}, "ChromeOS"},
+		{[]string{"Mac OS X", "Macintosh"}, "macOS"},
+		{[]string{"Linux"}, "Linux"},
+	}
+)
+
+// ParseUserAgent extracts browser, OS and device type from a User-Agent header.
+func ParseUserAgent(userAgent string) DeviceInfo {
+	info := DeviceInfo{
+		Browser:    matchUaRule(userAgent, browserRules),
+		OS:         matchUaRule(userAgent, osRules),
+		DeviceType: DeviceTypeOther,
+	}
+
+	lower := strings.ToLower(userAgent)
+
+	switch {
+	case userAgent == "":
+	case strings.Contains(lower, "bot") || strings.Contains(lower, "crawler") || strings.Contains(lower, "spider"):
+		info.DeviceType = DeviceTypeBot
+	case strings.Contains(userAgent, "iPad") || strings.Contains(lower, "tablet") ||
+		(info.OS == "Android" && !strings.Contains(userAgent, "Mobile")):
+		info.DeviceType = DeviceTypeTablet
+	case strings.Contains(userAgent, "Mobi") || strings.Contains(userAgent, "iPhone") || info.OS == "Android":
+		info.DeviceType = DeviceTypeMobile
+	case info.OS == "Windows" || info.OS == "macOS" || info.OS == "Linux" || info.OS == "ChromeOS":
+		info.DeviceType = DeviceTypeDesktop
+	}
+
+	return info
+}
+
+func matchUaRule(userAgent string, rules []uaRule) string {
+	for _, rule := range rules {
+		for _, token := range rule.tokens {
+			if strings.Contains(userAgent, token) {
+				return rule.name
+			}
+		}
+	}
+
+	return "Other"
+}
@@ -0,0 +1,113 @@
+// Package geoip implements coarse IP geolocation from an offline CSV range database.
+package geoip
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+)
+
+// Location -.
+type Location struct {
+	Country string
+	Region  string
+	City    string
+}
+
+type ipRange struct {
+	start    netip.Addr
+	end      netip.Addr
+	location Location
+}
+
+// GeoIP -.
+type GeoIP struct {
+	ranges []ipRange
+}
+
+// New loads a DB-IP style CSV database. Each row is either
+// "ip_start,ip_end,country" or "ip_start,ip_end,continent,country,region,city[,...]".
+func New(path string) (*GeoIP, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("geoip - New - os.Open: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	g := &GeoIP{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("geoip - New - reader.Read: %w", err)
+		}
+
+		if len(record) < 3 {
+			continue
+		}
+
+		start, err := netip.ParseAddr(record[0])
+		if err != nil {
+			continue
+		}
+
+		end, err := netip.ParseAddr(record[1])
+		if err != nil {
+			continue
+		}
+
+		item := ipRange{start: start.Unmap(), end: end.Unmap()}
+		if len(record) >= 6 {
+			item.location = Location{Country: record[3], Region: record[4], City: record[5]}
+		} else {
+			item.location = Location{Country: record[2]}
+		}
+
+		g.ranges = append(g.ranges, item)
+	}
+
+	sort.Slice(g.ranges, func(i, j int) bool {
+		return g.ranges[i].start.Less(g.ranges[j].start)
+	})
+
+	return g, nil
+}
+
+// Lookup returns the location of the given IP address, or an empty Location when it is unknown.
+// It is safe to call on a nil GeoIP, which means geolocation is disabled.
+func (g *GeoIP) Lookup(ip string) Location {
+	if g == nil {
+		return Location{}
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return Location{}
+	}
+	addr = addr.Unmap()
+
+	// first range starting after addr; the candidate is the one right before it
+	i := sort.Search(len(g.ranges), func(i int) bool {
+		return addr.Less(g.ranges[i].start)
+	})
+	if i == 0 {
+		return Location{}
+	}
+
+	item := g.ranges[i-1]
+	if item.end.Less(addr) {
+		return Location{}
+	}
+
+	return item.location
+}